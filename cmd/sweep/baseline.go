@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/baseline"
+)
+
+// runBaseline implements `kyubisweep baseline`: it scans and records every
+// finding, of any severity, as accepted. `kyubisweep scan --baseline` then
+// reports only findings that are not in the file.
+func runBaseline(fs *flag.FlagSet, args []string) {
+	var opts scanOptions
+	opts.register(fs)
+	out := fs.String("out", baseline.DefaultPath, "Baseline `file` to write")
+	positional := parseArgs(fs, args)
+
+	scan := opts.run(positional, nil)
+	if scan.result.Incomplete {
+		fmt.Println("❌ Scan interrupted - baseline not written")
+		os.Exit(130)
+	}
+
+	b, err := baseline.Write(*out, scan.result.Findings)
+	if err != nil {
+		fmt.Printf("❌ Could not write baseline: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📌 Recorded %d known findings in %s\n", b.Len(), *out)
+	fmt.Printf("   Run `kyubisweep scan --baseline %s` to report only new ones\n", *out)
+}
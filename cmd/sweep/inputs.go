@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/engine"
+)
+
+// stdinArg is the positional argument that selects standard input.
+const stdinArg = "-"
+
+// pathList is a repeatable string flag, e.g. --path a --path b.
+type pathList []string
+
+func (p *pathList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *pathList) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// parseInterleaved parses args with fs, allowing flags after positional
+// arguments (the flag package stops at the first one), and returns the
+// positional arguments. "--" ends flag parsing as usual.
+func parseInterleaved(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		// Errors exit: the flag sets use flag.ExitOnError
+		_ = fs.Parse(args)
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			// Everything after "--" is positional
+			return append(positional, rest...)
+		}
+		if len(rest) == 0 {
+			return positional
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// scanInputs lists everything a single run scans.
+type scanInputs struct {
+	roots     []string // directories or files, walked with the usual filters
+	files     []string // explicit files, scanned regardless of extension
+	stdin     bool
+	stdinName string // virtual file name for stdin content
+}
+
+// targets describes the inputs for the report header.
+func (in scanInputs) targets() string {
+	parts := append([]string(nil), in.roots...)
+	if len(in.files) > 0 {
+		parts = append(parts, fmt.Sprintf("%d listed files", len(in.files)))
+	}
+	if in.stdin {
+		parts = append(parts, fmt.Sprintf("stdin (as %s)", in.stdinName))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// onDisk drops findings in stdin content, which has no file to quarantine.
+func (in scanInputs) onDisk(findings []analyzer.Finding) []analyzer.Finding {
+	if !in.stdin {
+		return findings
+	}
+	kept := make([]analyzer.Finding, 0, len(findings))
+	for _, f := range findings {
+		if f.FilePath != in.stdinName {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// resolveInputs builds the scan inputs from --path values, positional
+// arguments ("-" for stdin) and --files-from. With no input at all the
+// current directory is scanned.
+func resolveInputs(paths, args []string, filesFrom, stdinName string) (scanInputs, error) {
+	in := scanInputs{stdinName: stdinName}
+
+	seen := make(map[string]bool)
+	for _, p := range append(append([]string(nil), paths...), args...) {
+		if p == stdinArg {
+			in.stdin = true
+			continue
+		}
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return in, fmt.Errorf("resolving %s: %w", p, err)
+		}
+		if !seen[abs] {
+			seen[abs] = true
+			in.roots = append(in.roots, abs)
+		}
+	}
+
+	if filesFrom != "" {
+		if filesFrom == stdinArg && in.stdin {
+			return in, errors.New("stdin cannot be both scanned and used for --files-from")
+		}
+		var r io.Reader = os.Stdin
+		if filesFrom != stdinArg {
+			file, err := os.Open(filesFrom)
+			if err != nil {
+				return in, err
+			}
+			defer file.Close()
+			r = file
+		}
+		files, err := readFileList(r)
+		if err != nil {
+			return in, fmt.Errorf("reading --files-from: %w", err)
+		}
+		for _, f := range files {
+			abs, err := filepath.Abs(f)
+			if err != nil {
+				return in, fmt.Errorf("resolving %s: %w", f, err)
+			}
+			in.files = append(in.files, abs)
+		}
+	}
+
+	if len(in.roots) == 0 && len(in.files) == 0 && !in.stdin {
+		abs, err := filepath.Abs(".")
+		if err != nil {
+			return in, err
+		}
+		in.roots = []string{abs}
+	}
+	return in, nil
+}
+
+// readFileList reads a list of paths separated by newlines, or by NUL bytes
+// if there are any (as produced by find -print0 or git ls-files -z).
+func readFileList(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	sep := []byte("\n")
+	if bytes.IndexByte(data, 0) >= 0 {
+		sep = []byte{0}
+	}
+
+	var files []string
+	for _, entry := range bytes.Split(data, sep) {
+		path := strings.TrimSuffix(string(entry), "\r")
+		if sep[0] == '\n' {
+			path = strings.TrimSpace(path)
+		}
+		if path != "" {
+			files = append(files, path)
+		}
+	}
+	return files, nil
+}
+
+// collectFindings scans every input with one engine and returns the
+// combined findings and stats. Cancelling ctx ends the scan early with the
+// stats marked incomplete.
+func collectFindings(ctx context.Context, in scanInputs, cfg engine.Config) ([]analyzer.Finding, engine.Stats) {
+	e := engine.New(cfg)
+
+	var findings []analyzer.Finding
+	collect := func(f analyzer.Finding) {
+		findings = append(findings, f)
+	}
+
+	var stats engine.Stats
+	if len(in.roots) > 0 || len(in.files) > 0 {
+		s, _ := e.ScanPaths(ctx, in.roots, in.files, collect)
+		stats.Add(s)
+	}
+	if in.stdin && ctx.Err() == nil {
+		s, err := e.ScanReader(ctx, in.stdinName, os.Stdin, collect)
+		if err != nil && ctx.Err() == nil {
+			fmt.Printf("⚠️  Could not read stdin: %v\n", err)
+		}
+		stats.Add(s)
+	}
+	stats.Incomplete = stats.Incomplete || ctx.Err() != nil
+
+	return findings, stats
+}
@@ -2,24 +2,16 @@
 package main
 
 import (
-	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
-	"path/filepath"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
-	"github.com/tanmayshahane/kyubisweep/pkg/quarantine"
-	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
-	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+	"github.com/tanmayshahane/kyubisweep/pkg/common"
 )
 
 const (
-	numWorkers = 10
-
 	bannerArt = `
     ╔═══════════════════════════════════════════════════════════════╗
     ║                                                               ║
@@ -31,225 +23,313 @@ const (
 `
 )
 
-func main() {
-	// Define command-line flags
-	scanPath := flag.String("path", ".", "Path to the directory to scan for secrets")
-	verbose := flag.Bool("verbose", false, "Enable verbose output for debugging")
-	showHelp := flag.Bool("help", false, "Show help information")
-	allSeverity := flag.Bool("all", false, "Show all severity levels (default: HIGH only)")
-	allFiles := flag.Bool("all-files", false, "Scan all files, not just text-based files")
-	extraExt := flag.String("ext", "", "Additional file extensions to scan (comma-separated)")
-	outputJSON := flag.Bool("json", false, "Output report as JSON file")
-	noReport := flag.Bool("no-report", false, "Don't save report file")
-	quiet := flag.Bool("quiet", false, "Minimal output, just summary stats")
-	moveTo := flag.String("move-to", "", "Quarantine: Move files with secrets to this directory")
-
-	flag.Parse()
-
-	if *showHelp {
-		printHelp()
-		os.Exit(0)
-	}
+// command is one kyubisweep subcommand. run registers the command's flags
+// on the FlagSet it is given before parsing, so help is generated from the
+// flags themselves.
+type command struct {
+	name     string
+	args     string // positional arguments, shown in usage
+	summary  string
+	run      func(fs *flag.FlagSet, args []string)
+	commands []*command // subcommands, e.g. `rules list`; run is unused when set
+}
 
-	startTime := time.Now()
+// commands lists every subcommand. Arguments that do not start with a
+// command name run scan, so `kyubisweep --path .` keeps working.
+var commands = []*command{
+	{name: "scan", args: "[PATH...]", summary: "Scan files, directories or stdin (-) for secrets and save a report", run: runScan},
+	{name: "quarantine", args: "[PATH...]", summary: "Scan, then move files with secrets into a vault", run: runQuarantine},
+	{name: "restore", args: "VAULT", summary: "Move quarantined files back to where they came from", run: runRestore},
+	{name: "baseline", args: "[PATH...]", summary: "Record current findings so later scans only report new ones", run: runBaseline},
+	{name: "watch", summary: "Report secrets in files as they are written", run: runWatch},
+	{name: "redact", summary: "Copy stdin to stdout with secrets masked", run: runRedact},
+	{name: "rules", summary: "Inspect the detection rules", commands: []*command{
+		{name: "list", summary: "List the detection rules and providers", run: runRulesList},
+		{name: "test", args: "FILE|-", summary: "Show which rules match a file or stdin", run: runRulesTest},
+	}},
+	{name: "report", summary: "Work with saved reports", commands: []*command{
+		{name: "convert", args: "REPORT.json", summary: "Render a saved JSON report as Markdown or HTML", run: runReportConvert},
+	}},
+}
 
-	if !*quiet {
-		fmt.Print(bannerArt)
-	}
+// examples are shown at the end of the main help.
+var examples = []string{
+	"kyubisweep scan ./my-project",
+	"kyubisweep scan --all --html --context 3 .",
+	"kubectl get secret -o yaml | kyubisweep scan --stdin-name secret.yaml -",
+	"git ls-files -z | kyubisweep scan --files-from -",
+	"kyubisweep baseline . && kyubisweep scan --baseline .kyubisweep-baseline.json .",
+	"kyubisweep quarantine --to ./secure_vault . && kyubisweep restore ./secure_vault",
+	"kyubisweep watch --path ~/code --jsonl alerts.jsonl",
+	"./build.sh 2>&1 | kyubisweep redact --stdin-name build.log | upload-logs",
+	"kyubisweep rules test config.env",
+	"kyubisweep report convert --to html reports/kyubisweep_2024-01-01_12-00-00.json",
+}
 
-	absPath, err := filepath.Abs(*scanPath)
-	if err != nil {
-		fmt.Printf("❌ Error resolving path: %v\n", err)
-		os.Exit(1)
-	}
+// globalOptions are accepted by every command, before or after its name.
+type globalOptions struct {
+	noColor    bool
+	quiet      bool
+	reportsDir string
+}
 
-	if !*quiet {
-		fmt.Printf("🔍 Scanning: %s\n", absPath)
-		if *verbose {
-			fmt.Println("📢 Verbose mode enabled")
+var globals globalOptions
+
+// register adds the global options to fs.
+func (g *globalOptions) register(fs *flag.FlagSet) {
+	fs.BoolVar(&g.noColor, "no-color", os.Getenv("NO_COLOR") != "", "Disable colored output (implied by the NO_COLOR environment variable or when output is not a terminal)")
+	fs.BoolVar(&g.quiet, "quiet", false, "Minimal output: no banner or progress messages")
+	fs.StringVar(&g.reportsDir, "reports-dir", "reports", "Save reports to this `directory`")
+}
+
+// isGlobalFlag reports whether name is one of the global options.
+func isGlobalFlag(name string) bool {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	new(globalOptions).register(fs)
+	return fs.Lookup(name) != nil
+}
+
+// leadingGlobals returns how many of args are global options that precede
+// the command name.
+func leadingGlobals(args []string) int {
+	n := 0
+	for n < len(args) {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[n], "-"), "=")
+		if !strings.HasPrefix(args[n], "-") || !isGlobalFlag(name) {
+			break
+		}
+		n++
+		if name == "reports-dir" && !hasValue {
+			n++ // the value is the next argument
 		}
 	}
+	return min(n, len(args))
+}
 
-	// Prepare extension filter
-	var allowedExtensions map[string]bool
-	if *allFiles {
-		allowedExtensions = nil
-	} else if *extraExt != "" {
-		extras := strings.Split(*extraExt, ",")
-		for i := range extras {
-			extras[i] = strings.TrimSpace(extras[i])
+func main() {
+	args := os.Args[1:]
+	n := leadingGlobals(args)
+	globalArgs, rest := args[:n], args[n:]
+
+	if len(rest) > 0 && isHelpArg(rest[0]) {
+		if rest[0] == "help" && len(rest) > 1 {
+			// `kyubisweep help rules test` is `kyubisweep rules test --help`
+			dispatch("kyubisweep", commands, globalArgs, append(rest[1:], "--help"))
+			return
 		}
-		allowedExtensions = scanner.MergeExtensions(extras)
-	} else {
-		allowedExtensions = scanner.DefaultTextExtensions
+		printHelp(globalArgs)
+		return
 	}
 
-	findings, fileCount := runScan(absPath, *verbose, *allSeverity, allowedExtensions)
+	dispatch("kyubisweep", commands, globalArgs, rest)
+}
 
-	endTime := time.Now()
+// dispatch runs the command named by args[0] with the remaining arguments.
+// At the top level, arguments that do not name a command are passed to scan.
+func dispatch(path string, cmds []*command, globalArgs, args []string) {
+	n := leadingGlobals(args)
+	globalArgs, args = append(append([]string(nil), globalArgs...), args[:n]...), args[n:]
 
-	// Create scan result
-	result := reporter.ScanResult{
-		ScanPath:     absPath,
-		StartTime:    startTime,
-		EndTime:      endTime,
-		FilesScanned: fileCount,
-		Findings:     findings,
+	var cmd *command
+	if len(args) > 0 {
+		cmd = findCommand(cmds, args[0])
 	}
-
-	// Print the Security Hygiene Scorecard
-	reporter.PrintScorecard(result)
-
-	// Save reports
-	if !*noReport {
-		if *outputJSON {
-			saveJSONReport(result)
-		} else {
-			reportPath, err := reporter.SaveMarkdownReport(result, "reports")
-			if err != nil {
-				fmt.Printf("⚠️  Could not save report: %v\n", err)
-			} else {
-				fmt.Printf("  📁 Report saved: %s\n\n", reportPath)
-			}
+	switch {
+	case cmd != nil:
+		args = args[1:]
+	case path == "kyubisweep":
+		cmd = findCommand(cmds, "scan")
+	default:
+		if len(args) > 0 && !isHelpArg(args[0]) {
+			fmt.Printf("❌ Unknown command: %s %s\n\n", path, args[0])
+			printGroupHelp(path, cmds, globalArgs)
+			os.Exit(2)
 		}
+		printGroupHelp(path, cmds, globalArgs)
+		return
 	}
 
-	// Handle quarantine if requested
-	if *moveTo != "" && len(findings) > 0 {
-		handleQuarantine(findings, *moveTo)
+	path += " " + cmd.name
+	if len(cmd.commands) > 0 {
+		dispatch(path, cmd.commands, globalArgs, args)
+		return
 	}
+
+	fs := flag.NewFlagSet(path, flag.ExitOnError)
+	fs.SetOutput(os.Stdout)
+	globals.register(fs)
+	fs.Usage = func() { printCommandHelp(path, cmd, fs) }
+	cmd.run(fs, append(globalArgs, args...))
 }
 
-func handleQuarantine(findings []analyzer.Finding, targetDir string) {
-	// Get unique file paths
-	uniqueFiles := make(map[string]bool)
-	for _, f := range findings {
-		uniqueFiles[f.FilePath] = true
+func findCommand(cmds []*command, name string) *command {
+	for _, cmd := range cmds {
+		if cmd.name == name {
+			return cmd
+		}
 	}
+	return nil
+}
 
-	filePaths := make([]string, 0, len(uniqueFiles))
-	for path := range uniqueFiles {
-		filePaths = append(filePaths, path)
+func isHelpArg(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+// parseArgs parses a command's arguments, allowing flags after positional
+// arguments, applies the global options and returns the positional
+// arguments.
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	positional := parseInterleaved(fs, args)
+	if globals.noColor || !isTerminal(os.Stdout) {
+		common.DisableColor()
 	}
+	return positional
+}
 
-	// Ask for confirmation
-	if !quarantine.ConfirmQuarantine(len(filePaths), targetDir) {
-		fmt.Println("\n  ❌ Quarantine cancelled by user.")
-		return
+// applyGlobals parses global options given before a group's subcommand,
+// for help output.
+func applyGlobals(globalArgs []string) {
+	fs := flag.NewFlagSet("kyubisweep", flag.ContinueOnError)
+	globals.register(fs)
+	if fs.Parse(globalArgs) == nil && (globals.noColor || !isTerminal(os.Stdout)) {
+		common.DisableColor()
 	}
+}
 
-	// Perform the quarantine
-	fmt.Println("\n  📦 Moving files to quarantine...")
+// isTerminal reports whether f is a character device such as a terminal,
+// so color is left out when output is piped or redirected to a file.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
-	results, err := quarantine.QuarantineFiles(filePaths, targetDir)
-	if err != nil {
-		fmt.Printf("  ❌ Quarantine failed: %v\n", err)
-		return
+// splitList parses a comma-separated flag value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
 
-	// Show results
-	quarantine.PrintQuarantineResults(results)
+// checkAnalyzerOptions exits with a usage error when opts names providers
+// that do not exist, rather than scanning with fewer rules than intended.
+func checkAnalyzerOptions(opts analyzer.Options) {
+	if err := opts.Validate(); err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(2)
+	}
 }
 
-func printHelp() {
+// printBanner shows the banner unless --quiet is set.
+func printBanner() {
+	if !globals.quiet {
+		fmt.Print(bannerArt)
+	}
+}
+
+func printHelp(globalArgs []string) {
+	applyGlobals(globalArgs)
 	fmt.Print(bannerArt)
 	fmt.Println("USAGE:")
-	fmt.Println("  kyubisweep [OPTIONS]")
+	fmt.Println("  kyubisweep [GLOBAL OPTIONS] <command> [OPTIONS] [ARGS]")
+	fmt.Println("  kyubisweep [OPTIONS] [PATH...]     Same as `kyubisweep scan`")
+	fmt.Println("")
+	fmt.Println("COMMANDS:")
+	printCommandList("", commands)
 	fmt.Println("")
-	fmt.Println("OPTIONS:")
-	fmt.Println("  --path <directory>   Path to scan (default: current directory)")
-	fmt.Println("  --verbose            Enable detailed output")
-	fmt.Println("  --all                Show all severity levels (default: HIGH only)")
-	fmt.Println("  --all-files          Scan all files, not just text-based files")
-	fmt.Println("  --ext <extensions>   Additional extensions to scan (comma-separated)")
-	fmt.Println("  --json               Output report as JSON")
-	fmt.Println("  --no-report          Don't save report file")
-	fmt.Println("  --quiet              Minimal output, just summary")
-	fmt.Println("  --move-to <path>     Move files with secrets to quarantine directory")
-	fmt.Println("  --help               Show this help message")
+	printGlobalFlags()
+	fmt.Println("")
+	fmt.Println("Run `kyubisweep <command> --help` for the options of a command.")
 	fmt.Println("")
 	fmt.Println("EXAMPLES:")
-	fmt.Println("  kyubisweep --path ./my-project")
-	fmt.Println("  kyubisweep --path . --all")
-	fmt.Println("  kyubisweep --path . --move-to ./secure_vault")
-	fmt.Println("  kyubisweep --path . --json")
+	for _, example := range examples {
+		fmt.Printf("  %s\n", example)
+	}
+	fmt.Println("")
+	fmt.Println("PROVIDERS:")
+	fmt.Printf("  %s\n", strings.Join(analyzer.Providers(), ", "))
 	fmt.Println("")
 }
 
-func runScan(rootPath string, verbose bool, showAll bool, allowedExtensions map[string]bool) ([]analyzer.Finding, int) {
-	filePaths := make(chan string, 100)
-
-	go func() {
-		scanner.Walk(rootPath, filePaths, verbose, allowedExtensions)
-		close(filePaths)
-	}()
-
-	results := make(chan analyzer.Finding, 100)
-	fileCountCh := make(chan int, numWorkers)
-	var wg sync.WaitGroup
-
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-
-		go func(workerID int) {
-			defer wg.Done()
-			localCount := 0
-
-			for filePath := range filePaths {
-				localCount++
-				if verbose {
-					fmt.Printf("  [Worker %d] Analyzing: %s\n", workerID, filePath)
-				}
-
-				foundFindings := analyzer.AnalyzeFile(filePath)
+// printGroupHelp describes a command that only holds subcommands.
+func printGroupHelp(path string, cmds []*command, globalArgs []string) {
+	applyGlobals(globalArgs)
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s <command> [OPTIONS] [ARGS]\n", path)
+	fmt.Println("")
+	fmt.Println("COMMANDS:")
+	printCommandList("", cmds)
+	fmt.Println("")
+	printGlobalFlags()
+	fmt.Println("")
+}
 
-				for _, finding := range foundFindings {
-					results <- finding
-				}
-			}
-			fileCountCh <- localCount
-		}(i)
+// printCommandHelp describes a command from its flag definitions.
+func printCommandHelp(path string, cmd *command, fs *flag.FlagSet) {
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s [OPTIONS] %s\n", path, cmd.args)
+	fmt.Println("")
+	fmt.Printf("%s.\n", cmd.summary)
+	fmt.Println("")
+	own := 0
+	fs.VisitAll(func(f *flag.Flag) {
+		if !isGlobalFlag(f.Name) {
+			own++
+		}
+	})
+	if own > 0 {
+		fmt.Println("OPTIONS:")
+		printFlags(fs, false)
+		fmt.Println("")
 	}
+	printGlobalFlags()
+	fmt.Println("")
+}
 
-	go func() {
-		wg.Wait()
-		close(results)
-		close(fileCountCh)
-	}()
-
-	// Collect results
-	var allFindings []analyzer.Finding
-	for finding := range results {
-		// Filter by severity if needed
-		if !showAll && finding.Severity != "HIGH" {
+// printCommandList prints cmds and their subcommands with their summaries.
+func printCommandList(prefix string, cmds []*command) {
+	for _, cmd := range cmds {
+		if len(cmd.commands) > 0 {
+			printCommandList(prefix+cmd.name+" ", cmd.commands)
 			continue
 		}
-		allFindings = append(allFindings, finding)
-	}
-
-	// Count total files scanned
-	totalFiles := 0
-	for count := range fileCountCh {
-		totalFiles += count
+		fmt.Printf("  %-20s %s\n", prefix+cmd.name, cmd.summary)
 	}
+}
 
-	return allFindings, totalFiles
+func printGlobalFlags() {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	new(globalOptions).register(fs)
+	fmt.Println("GLOBAL OPTIONS:")
+	printFlags(fs, true)
 }
 
-func saveJSONReport(result reporter.ScanResult) {
-	reportsDir := "reports"
-	if err := os.MkdirAll(reportsDir, 0755); err != nil {
-		fmt.Printf("⚠️  Could not create reports directory: %v\n", err)
-		return
-	}
+// printFlags lists the flags of fs, either the global options or the
+// command's own, in the style of the rest of the help.
+func printFlags(fs *flag.FlagSet, global bool) {
+	fs.VisitAll(func(f *flag.Flag) {
+		if isGlobalFlag(f.Name) != global {
+			return
+		}
 
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	filename := filepath.Join(reportsDir, fmt.Sprintf("kyubisweep_%s.json", timestamp))
+		arg, usage := flag.UnquoteUsage(f)
+		name := "--" + f.Name
+		if arg != "" {
+			name += " <" + arg + ">"
+		}
+		switch f.DefValue {
+		case "", "false", "0", "0s":
+		default:
+			usage += fmt.Sprintf(" (default: %s)", f.DefValue)
+		}
 
-	data, _ := json.MarshalIndent(result, "", "  ")
-	if err := os.WriteFile(filename, data, 0644); err != nil {
-		fmt.Printf("⚠️  Could not save JSON report: %v\n", err)
-		return
-	}
-	fmt.Printf("  📁 Report saved: %s\n\n", filename)
+		if len(name) > 20 {
+			fmt.Printf("  %s\n  %-20s %s\n", name, "", usage)
+		} else {
+			fmt.Printf("  %-20s %s\n", name, usage)
+		}
+	})
 }
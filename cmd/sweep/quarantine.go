@@ -0,0 +1,100 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/quarantine"
+	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
+)
+
+// runQuarantine implements `kyubisweep quarantine`: it scans, shows the
+// scorecard and, once confirmed, moves every file with a finding into the
+// vault.
+func runQuarantine(fs *flag.FlagSet, args []string) {
+	var opts scanOptions
+	opts.register(fs)
+	vault := fs.String("to", "", "Vault `directory` files with secrets are moved to (required)")
+	allSeverity := fs.Bool("all", false, "Quarantine files with findings of any severity (default: HIGH only)")
+	positional := parseArgs(fs, args)
+
+	if *vault == "" {
+		fmt.Println("❌ --to is required: where should files be moved?")
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	filter := highOnly
+	if *allSeverity {
+		filter = nil
+	}
+	scan := opts.run(positional, filter)
+	reporter.PrintScorecard(scan.result)
+
+	if scan.result.Incomplete {
+		fmt.Println("⏭️  Not quarantining anything after an interrupted scan")
+		os.Exit(130)
+	}
+	if onDisk := scan.inputs.onDisk(scan.result.Findings); len(onDisk) > 0 {
+		handleQuarantine(onDisk, *vault)
+	}
+}
+
+// runRestore implements `kyubisweep restore`: it moves the files recorded
+// in a vault's manifest back to where they were quarantined from.
+func runRestore(fs *flag.FlagSet, args []string) {
+	positional := parseArgs(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	vault := positional[0]
+
+	printBanner()
+	results, err := quarantine.RestoreFiles(vault)
+	if len(results) > 0 {
+		quarantine.PrintRestoreResults(results)
+	}
+	if err != nil {
+		fmt.Printf("  ❌ Restore failed: %v\n", err)
+		os.Exit(1)
+	}
+	for _, r := range results {
+		if !r.Success {
+			os.Exit(1)
+		}
+	}
+}
+
+func handleQuarantine(findings []analyzer.Finding, targetDir string) {
+	// Get unique file paths
+	uniqueFiles := make(map[string]bool)
+	for _, f := range findings {
+		uniqueFiles[f.FilePath] = true
+	}
+
+	filePaths := make([]string, 0, len(uniqueFiles))
+	for path := range uniqueFiles {
+		filePaths = append(filePaths, path)
+	}
+
+	// Ask for confirmation
+	if !quarantine.ConfirmQuarantine(len(filePaths), targetDir) {
+		fmt.Println("\n  ❌ Quarantine cancelled by user.")
+		return
+	}
+
+	// Perform the quarantine
+	fmt.Println("\n  📦 Moving files to quarantine...")
+
+	results, err := quarantine.QuarantineFiles(filePaths, targetDir)
+	if len(results) > 0 {
+		// Show results
+		quarantine.PrintQuarantineResults(results)
+	}
+	if err != nil {
+		fmt.Printf("  ❌ Quarantine failed: %v\n", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/redact"
+)
+
+// runRedact implements `kyubisweep redact`: it copies stdin to stdout with
+// every secret masked, then reports the number of redactions on stderr.
+func runRedact(fs *flag.FlagSet, args []string) {
+	jsonLines := fs.Bool("json", false, "Treat each line as JSON and only redact inside strings")
+	stdinName := fs.String("stdin-name", redact.DefaultConfig().Name, "File `name` the input is analyzed as, e.g. build.log")
+	providers := fs.String("providers", "", "Only run rules for these `providers` (comma-separated)")
+	disableProviders := fs.String("disable-providers", "", "Skip rules for these `providers` (comma-separated)")
+	scanPII := fs.Bool("pii", false, "Also redact personal data (card numbers, IBANs, SSNs, emails)")
+	parseArgs(fs, args)
+
+	opts := analyzer.Options{
+		EnabledProviders:  splitList(*providers),
+		DisabledProviders: splitList(*disableProviders),
+		EnablePII:         *scanPII,
+	}
+	checkAnalyzerOptions(opts)
+
+	r := redact.New(redact.Config{
+		Name:     *stdinName,
+		JSON:     *jsonLines,
+		Analyzer: opts,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Reading stdin blocks, so wait for the stream or a signal, whichever
+	// comes first; lines already read have been written out.
+	done := make(chan error, 1)
+	go func() {
+		done <- r.Stream(ctx, os.Stdin, os.Stdout)
+	}()
+
+	select {
+	case err := <-done:
+		fmt.Fprintf(os.Stderr, "🔒 Redactions: %d\n", r.Count())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Redaction failed: %v\n", err)
+			os.Exit(1)
+		}
+	case <-ctx.Done():
+		fmt.Fprintf(os.Stderr, "🔒 Redactions: %d (interrupted)\n", r.Count())
+		os.Exit(130)
+	}
+}
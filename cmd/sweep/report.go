@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
+)
+
+// runReportConvert implements `kyubisweep report convert`: it renders a
+// report saved with `scan --json` in another format, without rescanning.
+func runReportConvert(fs *flag.FlagSet, args []string) {
+	format := fs.String("to", "md", "Output `format`: md or html")
+	positional := parseArgs(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	result, err := reporter.LoadJSONReport(positional[0])
+	if err != nil {
+		fmt.Printf("❌ %v\n", err)
+		os.Exit(1)
+	}
+
+	var reportPath string
+	switch *format {
+	case "md", "markdown":
+		reportPath, err = reporter.SaveMarkdownReport(result, globals.reportsDir)
+	case "html":
+		reportPath, err = reporter.SaveHTMLReport(result, globals.reportsDir)
+	default:
+		fmt.Printf("❌ Unknown format %q: use md or html\n", *format)
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Printf("❌ Could not save report: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("📁 Report saved: %s\n", reportPath)
+}
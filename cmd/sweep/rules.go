@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/common"
+	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
+)
+
+// runRulesList implements `kyubisweep rules list`.
+func runRulesList(fs *flag.FlagSet, args []string) {
+	provider := fs.String("provider", "", "Only list rules of this `provider`")
+	parseArgs(fs, args)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tPROVIDER\tSEVERITY")
+	count := 0
+	for _, rule := range analyzer.Rules() {
+		if *provider != "" && rule.Provider != *provider {
+			continue
+		}
+		severity := rule.Severity
+		if rule.Category == analyzer.CategoryPII {
+			severity += " (PII, needs --pii)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", rule.Name, rule.Provider, severity)
+		count++
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d rules. Providers (for --providers and --disable-providers):\n  %s\n",
+		count, strings.Join(analyzer.Providers(), ", "))
+}
+
+// runRulesTest implements `kyubisweep rules test`: it analyzes one file, or
+// stdin, and lists every finding with the rules that produced it. It exits
+// with status 1 when nothing matched, so it can be used in scripts.
+func runRulesTest(fs *flag.FlagSet, args []string) {
+	stdinName := fs.String("stdin-name", "stdin", "File `name` used for content piped on stdin, e.g. secret.yaml")
+	providers := fs.String("providers", "", "Only run rules for these `providers` (comma-separated)")
+	disableProviders := fs.String("disable-providers", "", "Skip rules for these `providers` (comma-separated)")
+	scanPII := fs.Bool("pii", false, "Also run the personal data rules")
+	positional := parseArgs(fs, args)
+	if len(positional) != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	opts := analyzer.Options{
+		EnabledProviders:  splitList(*providers),
+		DisabledProviders: splitList(*disableProviders),
+		EnablePII:         *scanPII,
+	}
+	checkAnalyzerOptions(opts)
+
+	var findings []analyzer.Finding
+	var err error
+	if positional[0] == stdinArg {
+		findings, err = analyzer.AnalyzeReader(context.Background(), *stdinName, os.Stdin, opts)
+	} else {
+		findings, err = analyzer.AnalyzeFileContext(context.Background(), positional[0], opts)
+	}
+	if err != nil {
+		fmt.Printf("❌ Could not analyze %s: %v\n", positional[0], err)
+		os.Exit(1)
+	}
+
+	if len(findings) == 0 {
+		fmt.Println("No rules matched")
+		os.Exit(1)
+	}
+	for _, f := range findings {
+		fmt.Printf("  %s\n", reporter.FindingLine(f))
+		if len(f.SecondaryMatches) > 0 {
+			fmt.Printf("      %s\n", common.Colorize("also matched: "+strings.Join(f.SecondaryMatches, ", "), common.ColorDim))
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"syscall"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/baseline"
+	"github.com/tanmayshahane/kyubisweep/pkg/cache"
+	"github.com/tanmayshahane/kyubisweep/pkg/engine"
+	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
+	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+	"github.com/tanmayshahane/kyubisweep/pkg/verify"
+)
+
+// scanOptions are the flags shared by the commands that run a scan.
+type scanOptions struct {
+	paths            pathList
+	filesFrom        string
+	stdinName        string
+	allFiles         bool
+	extraExt         string
+	providers        string
+	disableProviders string
+	pii              bool
+	contextLines     int
+	cachePath        string
+	workers          int
+	verbose          bool
+}
+
+// register adds the input, rule and engine flags to fs.
+func (o *scanOptions) register(fs *flag.FlagSet) {
+	fs.Var(&o.paths, "path", "Scan this `path`; repeat for several roots (default: current directory)")
+	fs.StringVar(&o.filesFrom, "files-from", "", "Scan the files listed in this `file`, newline or NUL separated (\"-\" for stdin)")
+	fs.StringVar(&o.stdinName, "stdin-name", "stdin", "File `name` used for content piped on stdin, e.g. secret.yaml")
+	fs.BoolVar(&o.allFiles, "all-files", false, "Scan all files, not just text-based files")
+	fs.StringVar(&o.extraExt, "ext", "", "Additional file `extensions` to scan (comma-separated)")
+	fs.StringVar(&o.providers, "providers", "", "Only run rules for these `providers` (comma-separated)")
+	fs.StringVar(&o.disableProviders, "disable-providers", "", "Skip rules for these `providers` (comma-separated)")
+	fs.BoolVar(&o.pii, "pii", false, "Also detect personal data (card numbers, IBANs, SSNs, emails)")
+	fs.StringVar(&o.cachePath, "cache", "", "Reuse findings for unchanged files from this cache `file`")
+	fs.IntVar(&o.workers, "workers", runtime.NumCPU(), "Process `n` files and directories in parallel")
+	fs.BoolVar(&o.verbose, "verbose", false, "Enable verbose output for debugging")
+}
+
+// analyzerOptions returns the rule selection made by the flags.
+func (o *scanOptions) analyzerOptions() analyzer.Options {
+	return analyzer.Options{
+		EnabledProviders:  splitList(o.providers),
+		DisabledProviders: splitList(o.disableProviders),
+		EnablePII:         o.pii,
+		ContextLines:      o.contextLines,
+	}
+}
+
+// scanOutcome is what a scan found, ready to report.
+type scanOutcome struct {
+	inputs scanInputs
+	result reporter.ScanResult
+}
+
+// run scans the inputs named by the flags and positional arguments, keeping
+// the findings accepted by filter (all of them when nil). The first Ctrl-C
+// stops the scan and keeps what was found so far; a second one kills the
+// process.
+func (o *scanOptions) run(positional []string, filter func(analyzer.Finding) bool) scanOutcome {
+	startTime := time.Now()
+	analyzerOpts := o.analyzerOptions()
+	checkAnalyzerOptions(analyzerOpts)
+	printBanner()
+
+	inputs, err := resolveInputs(o.paths, positional, o.filesFrom, o.stdinName)
+	if err != nil {
+		fmt.Printf("❌ Error resolving inputs: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !globals.quiet {
+		fmt.Printf("🔍 Scanning: %s\n", inputs.targets())
+		if o.verbose {
+			fmt.Println("📢 Verbose mode enabled")
+		}
+	}
+
+	// Prepare extension filter
+	var allowedExtensions map[string]bool
+	if o.allFiles {
+		allowedExtensions = nil
+	} else if o.extraExt != "" {
+		allowedExtensions = scanner.MergeExtensions(splitList(o.extraExt))
+	} else {
+		allowedExtensions = scanner.DefaultTextExtensions
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	done := make(chan struct{})
+	defer func() {
+		// Close done first so the deferred stop is not taken for a signal
+		close(done)
+		stop()
+	}()
+	go func() {
+		select {
+		case <-ctx.Done():
+			select {
+			case <-done:
+				return // cancelled by the deferred stop, not a signal
+			default:
+			}
+			stop()
+			if !globals.quiet {
+				fmt.Println("\n⚠️  Interrupted - finishing up with partial results (press Ctrl-C again to abort)")
+			}
+		case <-done:
+		}
+	}()
+
+	var scanCache *cache.Cache
+	if o.cachePath != "" {
+		scanCache, err = cache.Open(o.cachePath, analyzerOpts.RulesVersion())
+		if err != nil {
+			fmt.Printf("⚠️  Could not open cache, scanning without it: %v\n", err)
+		}
+	}
+
+	scanConfig := engine.Config{
+		Workers:    o.workers,
+		Extensions: allowedExtensions,
+		Analyzer:   analyzerOpts,
+		Cache:      scanCache,
+		Filter:     filter,
+		OnError: func(filePath string, err error) {
+			fmt.Printf("⚠️  Could not analyze %s: %v\n", filePath, err)
+		},
+	}
+	if o.verbose {
+		scanConfig.OnFile = func(workerID int, filePath string) {
+			fmt.Printf("  [Worker %d] Analyzing: %s\n", workerID, filePath)
+		}
+	}
+
+	findings, stats := collectFindings(ctx, inputs, scanConfig)
+
+	if scanCache != nil {
+		if err := scanCache.Save(); err != nil {
+			fmt.Printf("⚠️  Could not save cache: %v\n", err)
+		}
+	}
+
+	return scanOutcome{
+		inputs: inputs,
+		result: reporter.ScanResult{
+			ScanPath:     inputs.targets(),
+			StartTime:    startTime,
+			EndTime:      time.Now(),
+			FilesScanned: stats.FilesScanned,
+			Findings:     findings,
+			Incomplete:   stats.Incomplete,
+			FilesSkipped: stats.FilesSkipped,
+			FilesCached:  stats.CacheHits,
+			FilesFailed:  stats.FilesFailed,
+		},
+	}
+}
+
+// highOnly is the default severity filter.
+func highOnly(f analyzer.Finding) bool {
+	return f.Severity == "HIGH"
+}
+
+// runScan implements `kyubisweep scan`, which is also what runs when no
+// command is given.
+func runScan(fs *flag.FlagSet, args []string) {
+	var opts scanOptions
+	opts.register(fs)
+	allSeverity := fs.Bool("all", false, "Show all severity levels (default: HIGH only)")
+	outputJSON := fs.Bool("json", false, "Output report as JSON file")
+	outputHTML := fs.Bool("html", false, "Output report as HTML file")
+	fs.IntVar(&opts.contextLines, "context", 0, "Include `n` lines of redacted context around each finding in reports (shown in the terminal with --verbose)")
+	noReport := fs.Bool("no-report", false, "Don't save report file")
+	moveTo := fs.String("move-to", "", "Quarantine: move files with secrets to this `directory` (see also `kyubisweep quarantine`)")
+	verifyLive := fs.Bool("verify", false, "Check found credentials against provider APIs (sends secrets over the network)")
+	baselinePath := fs.String("baseline", "", "Only report findings that are not in this baseline `file`")
+	positional := parseArgs(fs, args)
+
+	var known *baseline.Baseline
+	if *baselinePath != "" {
+		var err error
+		if known, err = baseline.Load(*baselinePath); err != nil {
+			fmt.Printf("❌ Could not load baseline: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	filter := highOnly
+	if *allSeverity {
+		filter = nil
+	}
+	scan := opts.run(positional, filter)
+	result := scan.result
+
+	if known != nil {
+		result.Findings, result.FindingsBaselined = known.Filter(result.Findings)
+	}
+
+	if *verifyLive && len(result.Findings) > 0 && !result.Incomplete {
+		if !globals.quiet {
+			fmt.Println("🔑 Verifying credentials against provider APIs...")
+		}
+		verify.NewRegistry(verify.DefaultConfig()).VerifyAll(context.Background(), result.Findings)
+	} else if *verifyLive && result.Incomplete && !globals.quiet {
+		fmt.Println("⏭️  Skipping credential verification for the interrupted scan")
+	}
+	result.EndTime = time.Now()
+
+	// Print the Security Hygiene Scorecard
+	reporter.PrintScorecard(result)
+	if opts.verbose && opts.contextLines > 0 {
+		reporter.PrintFindingContexts(result.Findings)
+	}
+
+	// Save reports
+	if !*noReport {
+		var reportPath string
+		var err error
+		switch {
+		case *outputJSON:
+			reportPath, err = reporter.SaveJSONReport(result, globals.reportsDir)
+		case *outputHTML:
+			reportPath, err = reporter.SaveHTMLReport(result, globals.reportsDir)
+		default:
+			reportPath, err = reporter.SaveMarkdownReport(result, globals.reportsDir)
+		}
+		if err != nil {
+			fmt.Printf("⚠️  Could not save report: %v\n", err)
+		} else {
+			fmt.Printf("  📁 Report saved: %s\n\n", reportPath)
+		}
+	}
+
+	// Handle quarantine if requested
+	if onDisk := scan.inputs.onDisk(result.Findings); *moveTo != "" && len(onDisk) > 0 {
+		handleQuarantine(onDisk, *moveTo)
+	}
+
+	if result.Incomplete {
+		os.Exit(130)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/reporter"
+	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+	"github.com/tanmayshahane/kyubisweep/pkg/watch"
+)
+
+// runWatch implements `kyubisweep watch`: it monitors a directory and
+// reports secrets in files as they are written, until interrupted.
+func runWatch(fs *flag.FlagSet, args []string) {
+	watchPath := fs.String("path", ".", "Watch this `directory`")
+	allSeverity := fs.Bool("all", false, "Report all severity levels (default: HIGH only)")
+	extraExt := fs.String("ext", "", "Additional file `extensions` to watch (comma-separated)")
+	providers := fs.String("providers", "", "Only run rules for these `providers` (comma-separated)")
+	disableProviders := fs.String("disable-providers", "", "Skip rules for these `providers` (comma-separated)")
+	scanPII := fs.Bool("pii", false, "Also detect personal data (card numbers, IBANs, SSNs, emails)")
+	debounce := fs.Duration("debounce", watch.DefaultConfig().Debounce, "Quiet period before changed files are scanned")
+	maxDelay := fs.Duration("max-delay", watch.DefaultConfig().MaxDelay, "Scan changed files after this long even if they keep changing")
+	poll := fs.Bool("poll", false, "Poll for changes instead of using filesystem notifications")
+	pollInterval := fs.Duration("poll-interval", watch.DefaultConfig().PollInterval, "How often to rescan when polling")
+	jsonlPath := fs.String("jsonl", "", "Append findings as JSON lines to this `file` (\"-\" for stdout)")
+	parseArgs(fs, args)
+
+	absPath, err := filepath.Abs(*watchPath)
+	if err != nil {
+		fmt.Printf("❌ Error resolving path: %v\n", err)
+		os.Exit(1)
+	}
+
+	var extensions map[string]bool
+	if *extraExt != "" {
+		extensions = scanner.MergeExtensions(splitList(*extraExt))
+	}
+
+	opts := analyzer.Options{
+		EnabledProviders:  splitList(*providers),
+		DisabledProviders: splitList(*disableProviders),
+		EnablePII:         *scanPII,
+	}
+	checkAnalyzerOptions(opts)
+
+	sink, closeSink, err := watchSink(*jsonlPath)
+	if err != nil {
+		fmt.Printf("❌ Could not open sink: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeSink()
+
+	w, err := watch.New(absPath, watch.Config{
+		Extensions:   extensions,
+		Debounce:     *debounce,
+		MaxDelay:     *maxDelay,
+		PollInterval: *pollInterval,
+		Polling:      *poll,
+	})
+	if err != nil {
+		fmt.Printf("❌ Cannot watch %s: %v\n", absPath, err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Keep stdout clean when it carries JSON lines
+	status := os.Stdout
+	if *jsonlPath == "-" {
+		status = os.Stderr
+	}
+	if !globals.quiet {
+		fmt.Fprint(status, bannerArt)
+	}
+	fmt.Fprintf(status, "👀 Watching: %s (%s, Ctrl-C to stop)\n\n", absPath, w.Backend())
+
+	tracker := watch.NewTracker()
+	err = w.Run(ctx, func(paths []string) {
+		for _, path := range paths {
+			findings, err := analyzer.AnalyzeFileContext(ctx, path, opts)
+			if err != nil {
+				continue
+			}
+
+			for _, f := range tracker.Fresh(path, findings) {
+				if !*allSeverity && f.Severity != "HIGH" {
+					continue
+				}
+				if err := sink.Emit(f); err != nil {
+					fmt.Fprintf(os.Stderr, "⚠️  Could not emit finding: %v\n", err)
+				}
+			}
+		}
+	})
+	if err != nil && ctx.Err() == nil {
+		fmt.Printf("❌ Watch failed: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintln(status, "\n👋 Stopped watching")
+}
+
+// watchSink returns where watch mode sends findings: the terminal, or a
+// JSON lines file when path is set.
+func watchSink(path string) (watch.Sink, func(), error) {
+	switch path {
+	case "":
+		return watch.SinkFunc(func(f analyzer.Finding) error {
+			fmt.Printf("  %s  %s\n", time.Now().Format("15:04:05"), reporter.FindingLine(f))
+			return nil
+		}), func() {}, nil
+	case "-":
+		return watch.NewJSONLinesSink(os.Stdout), func() {}, nil
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, err
+	}
+	return watch.NewJSONLinesSink(file), func() { file.Close() }, nil
+}
@@ -0,0 +1,315 @@
+package analyzer
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Certificate hygiene thresholds.
+const (
+	CertExpiryWarning = 30 * 24 * time.Hour
+	MinRSAKeyBits     = 2048
+	MinECDSAKeyBits   = 256
+)
+
+// maxSiblingKeySize bounds the neighbouring key files read when looking for
+// a certificate's private key.
+const maxSiblingKeySize = 1024 * 1024
+
+// certificateExtensions are the file types the certificate inspector runs on.
+var certificateExtensions = map[string]bool{
+	".crt": true,
+	".cer": true,
+	".pem": true,
+}
+
+// siblingKeyExtensions are the neighbouring files checked for a private key
+// matching a certificate.
+var siblingKeyExtensions = map[string]bool{
+	".key": true,
+	".pem": true,
+}
+
+var weakSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.MD2WithRSA:    true,
+	x509.MD5WithRSA:    true,
+	x509.SHA1WithRSA:   true,
+	x509.DSAWithSHA1:   true,
+	x509.ECDSAWithSHA1: true,
+}
+
+// parsedCert is a certificate together with the lines its PEM block spans.
+type parsedCert struct {
+	cert       *x509.Certificate
+	lineNumber int
+	endLine    int
+}
+
+// inspectCertificates parses the X.509 certificates in a .crt, .cer or .pem
+// file (PEM or raw DER) and reports expired or soon-to-expire certificates,
+// weak keys and signature algorithms, self-signed leaf certificates and
+// certificates stored next to their matching private key. Sibling key
+// files are only searched for when onDisk is set.
+func inspectCertificates(filePath string, data []byte, lines []string, onDisk bool) []Finding {
+	if !certificateExtensions[strings.ToLower(filepath.Ext(filePath))] {
+		return nil
+	}
+
+	certs := parseCertificates(data, lines)
+	if len(certs) == 0 {
+		return nil
+	}
+
+	keyFingerprints := privateKeyFingerprints(data)
+	if onDisk {
+		for fp := range siblingKeyFingerprints(filePath) {
+			keyFingerprints[fp] = true
+		}
+	}
+
+	findings := make([]Finding, 0)
+	for _, pc := range certs {
+		findings = append(findings, checkCertificate(filePath, pc, keyFingerprints)...)
+	}
+	return findings
+}
+
+// parseCertificates returns every certificate in data, which may be a PEM
+// bundle or a single DER certificate.
+func parseCertificates(data []byte, lines []string) []parsedCert {
+	certs := make([]parsedCert, 0)
+
+	if !bytes.Contains(data, []byte("-----BEGIN CERTIFICATE-----")) {
+		if cert, err := x509.ParseCertificate(data); err == nil {
+			certs = append(certs, parsedCert{cert: cert, lineNumber: 1, endLine: len(lines)})
+		}
+		return certs
+	}
+
+	beginLines := make([]int, 0)
+	endLines := make([]int, 0)
+	for i, line := range lines {
+		if strings.Contains(line, "-----BEGIN CERTIFICATE-----") {
+			beginLines = append(beginLines, i+1)
+		}
+		if strings.Contains(line, "-----END CERTIFICATE-----") {
+			endLines = append(endLines, i+1)
+		}
+	}
+
+	rest := data
+	index := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		lineNumber, endLine := 1, 1
+		if index < len(beginLines) {
+			lineNumber = beginLines[index]
+		}
+		if index < len(endLines) {
+			endLine = endLines[index]
+		}
+		index++
+
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, parsedCert{cert: cert, lineNumber: lineNumber, endLine: endLine})
+		}
+	}
+
+	return certs
+}
+
+func checkCertificate(filePath string, pc parsedCert, keyFingerprints map[string]bool) []Finding {
+	cert := pc.cert
+	findings := make([]Finding, 0)
+
+	details := map[string]string{
+		"subject":     cert.Subject.String(),
+		"issuer":      cert.Issuer.String(),
+		"not_after":   cert.NotAfter.UTC().Format(time.RFC3339),
+		"fingerprint": certFingerprint(cert),
+	}
+
+	add := func(findingType, severity, issue string) {
+		d := make(map[string]string, len(details)+1)
+		for k, v := range details {
+			d[k] = v
+		}
+		d["issue"] = issue
+
+		findings = append(findings, Finding{
+			FilePath:    filePath,
+			LineNumber:  pc.lineNumber,
+			EndLine:     pc.endLine,
+			Type:        findingType,
+			Match:       cert.Subject.CommonName,
+			Severity:    severity,
+			Confidence:  "HIGH",
+			Details:     d,
+			specificity: specificityProvider,
+		})
+	}
+
+	switch remaining := cert.NotAfter.Sub(now()); {
+	case remaining <= 0:
+		add("Expired Certificate", "MEDIUM", fmt.Sprintf("expired %s", cert.NotAfter.UTC().Format("2006-01-02")))
+	case remaining <= CertExpiryWarning:
+		add("Expiring Certificate", "LOW", fmt.Sprintf("expires in %d days", int(remaining.Hours()/24)))
+	}
+
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if bits := key.N.BitLen(); bits < MinRSAKeyBits {
+			add("Weak Certificate Key", "MEDIUM", fmt.Sprintf("RSA key is %d bits", bits))
+		}
+	case *ecdsa.PublicKey:
+		if bits := key.Curve.Params().BitSize; bits < MinECDSAKeyBits {
+			add("Weak Certificate Key", "MEDIUM", fmt.Sprintf("ECDSA key is %d bits", bits))
+		}
+	}
+
+	if weakSignatureAlgorithms[cert.SignatureAlgorithm] {
+		add("Weak Certificate Signature", "MEDIUM", fmt.Sprintf("signed with %s", cert.SignatureAlgorithm))
+	}
+
+	if !cert.IsCA && bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil {
+		add("Self-Signed Certificate", "LOW", "leaf certificate is self-signed")
+	}
+
+	if keyFingerprints[fingerprint(cert.RawSubjectPublicKeyInfo)] {
+		add("Certificate With Private Key", "HIGH", "matching private key stored alongside certificate")
+	}
+
+	return findings
+}
+
+func certFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// privateKeyFingerprints parses every private key PEM block in data and
+// returns their public-key fingerprints.
+func privateKeyFingerprints(data []byte) map[string]bool {
+	fingerprints := make(map[string]bool)
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if !strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			continue
+		}
+		info, err := parsePrivateKeyBlock(string(pem.EncodeToMemory(block)))
+		if err == nil && info.fingerprint != "" {
+			fingerprints[info.fingerprint] = true
+		}
+	}
+
+	return fingerprints
+}
+
+// maxKeyDirs bounds how many directories siblingKeys remembers; it starts
+// over when full, so a long-running watch does not grow it without limit.
+const maxKeyDirs = 1024
+
+// keyDir is what siblingKeys remembers about one directory: the private-key
+// fingerprints of each small key file, valid while the directory's
+// modification time is unchanged.
+type keyDir struct {
+	modTime time.Time
+	files   map[string]map[string]bool // file name -> fingerprints
+}
+
+// siblingKeys caches the key files of each directory holding certificates,
+// so that a directory of N certificates is listed and its keys parsed once
+// rather than N times.
+var siblingKeys = struct {
+	sync.Mutex
+	dirs map[string]*keyDir
+}{dirs: make(map[string]*keyDir)}
+
+// siblingKeyFingerprints returns the fingerprints of the private keys in
+// small key files in the same directory as filePath. The directory is read
+// again only when its modification time changes, which creating, deleting
+// or renaming a file over a key does; a key rewritten in place is picked up
+// once the directory changes.
+func siblingKeyFingerprints(filePath string) map[string]bool {
+	dir := filepath.Dir(filePath)
+	dirInfo, err := os.Stat(dir)
+	if err != nil {
+		return nil
+	}
+
+	siblingKeys.Lock()
+	kd := siblingKeys.dirs[dir]
+	siblingKeys.Unlock()
+
+	if kd == nil || !kd.modTime.Equal(dirInfo.ModTime()) {
+		if kd = readKeyDir(dir, dirInfo.ModTime()); kd == nil {
+			return nil
+		}
+		siblingKeys.Lock()
+		if len(siblingKeys.dirs) >= maxKeyDirs {
+			siblingKeys.dirs = make(map[string]*keyDir)
+		}
+		siblingKeys.dirs[dir] = kd
+		siblingKeys.Unlock()
+	}
+
+	fingerprints := make(map[string]bool)
+	for name, fps := range kd.files {
+		if name == filepath.Base(filePath) {
+			continue
+		}
+		for fp := range fps {
+			fingerprints[fp] = true
+		}
+	}
+	return fingerprints
+}
+
+// readKeyDir reads the small key files in dir.
+func readKeyDir(dir string, modTime time.Time) *keyDir {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	kd := &keyDir{modTime: modTime, files: make(map[string]map[string]bool)}
+	for _, entry := range entries {
+		if entry.IsDir() || !siblingKeyExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Size() > maxSiblingKeySize {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err == nil {
+			kd.files[entry.Name()] = privateKeyFingerprints(data)
+		}
+	}
+	return kd
+}
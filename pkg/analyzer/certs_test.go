@@ -0,0 +1,180 @@
+package analyzer
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// TEST: Certificate Hygiene
+// Certificates are generated at runtime with controlled validity and keys
+// =============================================================================
+
+func makeCertPEM(t *testing.T, key interface{}, pub interface{}, notAfter time.Time, isCA bool) string {
+	t.Helper()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "service.internal"},
+		NotBefore:             notAfter.Add(-365 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, pub, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	return pemText("CERTIFICATE", der, nil)
+}
+
+func certFindingTypes(findings []Finding) map[string]bool {
+	types := make(map[string]bool)
+	for _, f := range findings {
+		types[f.Type] = true
+	}
+	return types
+}
+
+func TestInspectCertificates(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	current := time.Now()
+
+	tests := []struct {
+		name      string
+		pem       string
+		wantTypes []string
+		notTypes  []string
+	}{
+		{
+			name:      "expired self-signed leaf",
+			pem:       makeCertPEM(t, ecKey, &ecKey.PublicKey, current.Add(-24*time.Hour), false),
+			wantTypes: []string{"Expired Certificate", "Self-Signed Certificate"},
+		},
+		{
+			name:      "expiring soon",
+			pem:       makeCertPEM(t, ecKey, &ecKey.PublicKey, current.Add(7*24*time.Hour), true),
+			wantTypes: []string{"Expiring Certificate"},
+			notTypes:  []string{"Self-Signed Certificate"},
+		},
+		{
+			name:      "weak RSA key",
+			pem:       makeCertPEM(t, weakKey, &weakKey.PublicKey, current.Add(365*24*time.Hour), true),
+			wantTypes: []string{"Weak Certificate Key"},
+			notTypes:  []string{"Expired Certificate", "Expiring Certificate"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			types := certFindingTypes(inspectCertificates("server.crt", []byte(tc.pem), strings.Split(tc.pem, "\n"), false))
+
+			for _, want := range tc.wantTypes {
+				if !types[want] {
+					t.Errorf("Expected %q finding, got %v", want, types)
+				}
+			}
+			for _, unwanted := range tc.notTypes {
+				if types[unwanted] {
+					t.Errorf("Did not expect %q finding", unwanted)
+				}
+			}
+		})
+	}
+}
+
+func TestInspectCertificatesFindsSiblingKey(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	certPEM := makeCertPEM(t, key, &key.PublicKey, time.Now().Add(365*24*time.Hour), true)
+	keyDER, _ := x509.MarshalECPrivateKey(key)
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "tls.crt")
+	os.WriteFile(certPath, []byte(certPEM), 0644)
+	os.WriteFile(filepath.Join(dir, "tls.key"), []byte(pemText("EC PRIVATE KEY", keyDER, nil)), 0600)
+
+	lines := strings.Split(certPEM, "\n")
+	types := certFindingTypes(inspectCertificates(certPath, []byte(certPEM), lines, true))
+	if !types["Certificate With Private Key"] {
+		t.Errorf("Expected the matching sibling key to be reported, got %v", types)
+	}
+
+	types = certFindingTypes(inspectCertificates(certPath, []byte(certPEM), lines, false))
+	if types["Certificate With Private Key"] {
+		t.Errorf("Did not expect sibling keys to be read for in-memory content")
+	}
+}
+
+func TestSiblingKeysFollowDirectoryChanges(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	certPEM := makeCertPEM(t, key, &key.PublicKey, time.Now().Add(365*24*time.Hour), true)
+	lines := strings.Split(certPEM, "\n")
+
+	dir := t.TempDir()
+	certPaths := make([]string, 3)
+	for i := range certPaths {
+		certPaths[i] = filepath.Join(dir, fmt.Sprintf("tls%d.crt", i))
+		os.WriteFile(certPaths[i], []byte(certPEM), 0644)
+	}
+	keyPath := filepath.Join(dir, "tls.key")
+	// Keys are replaced the way tools save them: written aside, then renamed
+	writeKey := func(k *ecdsa.PrivateKey) {
+		der, _ := x509.MarshalECPrivateKey(k)
+		os.WriteFile(keyPath+".new", []byte(pemText("EC PRIVATE KEY", der, nil)), 0600)
+		os.Rename(keyPath+".new", keyPath)
+	}
+	withKey := func() bool {
+		found := 0
+		for _, path := range certPaths {
+			if certFindingTypes(inspectCertificates(path, []byte(certPEM), lines, true))["Certificate With Private Key"] {
+				found++
+			}
+		}
+		if found != 0 && found != len(certPaths) {
+			t.Errorf("Expected the certificates of one directory to agree, %d of %d found the key", found, len(certPaths))
+		}
+		return found > 0
+	}
+
+	steps := []struct {
+		name   string
+		change func()
+		want   bool
+	}{
+		{"no key", func() {}, false},
+		{"key added", func() { writeKey(key) }, true},
+		{"key replaced", func() { writeKey(other) }, false},
+		{"key removed", func() { os.Remove(keyPath) }, false},
+	}
+
+	for _, step := range steps {
+		step.change()
+		if got := withKey(); got != step.want {
+			t.Errorf("%s: found key = %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestInspectCertificatesIgnoresOtherExtensions(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	certPEM := makeCertPEM(t, key, &key.PublicKey, time.Now().Add(-time.Hour), false)
+
+	if findings := inspectCertificates("notes.txt", []byte(certPEM), strings.Split(certPEM, "\n"), false); len(findings) != 0 {
+		t.Errorf("Expected no certificate checks on .txt files, got %+v", findings)
+	}
+}
@@ -0,0 +1,61 @@
+package analyzer
+
+import "hash/crc32"
+
+// base62Alphabet is the digit ordering used by GitHub and npm when encoding
+// the CRC32 checksum at the end of their tokens.
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// Token layout shared by GitHub (ghp_, gho_, ghu_, ghs_, ghr_) and npm (npm_)
+// tokens: a 4-character prefix, 30 random characters and a 6-character
+// base62 CRC32 checksum of the random part.
+const (
+	checksumPrefixLen  = 4
+	checksumBodyLen    = 30
+	checksumDigitsLen  = 6
+	checksumTokenTotal = checksumPrefixLen + checksumBodyLen + checksumDigitsLen
+)
+
+// encodeBase62 encodes n in base62, left-padded with zeros to width.
+func encodeBase62(n uint32, width int) string {
+	var digits []byte
+	for n > 0 {
+		digits = append(digits, base62Alphabet[n%62])
+		n /= 62
+	}
+	for len(digits) < width {
+		digits = append(digits, '0')
+	}
+
+	// Digits were produced least significant first.
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// ValidTokenChecksum reports whether a GitHub or npm style token carries a
+// correct trailing CRC32 checksum.
+func ValidTokenChecksum(token string) bool {
+	if len(token) != checksumTokenTotal {
+		return false
+	}
+
+	body := token[checksumPrefixLen : checksumPrefixLen+checksumBodyLen]
+	want := token[checksumPrefixLen+checksumBodyLen:]
+
+	return encodeBase62(crc32.ChecksumIEEE([]byte(body)), checksumDigitsLen) == want
+}
+
+// inspectTokenChecksum raises confidence on tokens whose checksum verifies
+// and downgrades lookalikes to LOW so they only surface with --all.
+func inspectTokenChecksum(f *Finding) bool {
+	if ValidTokenChecksum(f.Match) {
+		f.Confidence = "HIGH"
+		return true
+	}
+
+	f.Confidence = "LOW"
+	f.Severity = "LOW"
+	return true
+}
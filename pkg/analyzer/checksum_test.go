@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"hash/crc32"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Token Checksums
+// Tokens are assembled at runtime to avoid GitHub secret scanning triggers
+// =============================================================================
+
+func makeChecksumToken(prefix, body string) string {
+	return prefix + body + encodeBase62(crc32.ChecksumIEEE([]byte(body)), checksumDigitsLen)
+}
+
+func TestEncodeBase62(t *testing.T) {
+	tests := []struct {
+		input uint32
+		want  string
+	}{
+		{0, "000000"},
+		{61, "00000z"},
+		{62, "000010"},
+		{4294967295, "4gfFC3"},
+	}
+
+	for _, tc := range tests {
+		if got := encodeBase62(tc.input, 6); got != tc.want {
+			t.Errorf("encodeBase62(%d) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestValidTokenChecksum(t *testing.T) {
+	body := "R4nd0mB0dyF0rT0k3nT3st1ngAbCd"[:29] + "Z"
+	valid := makeChecksumToken("gh"+"p_", body)
+
+	tests := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{"valid GitHub token", valid, true},
+		{"valid npm token", makeChecksumToken("np"+"m_", body), true},
+		{"corrupted checksum", valid[:len(valid)-1] + "x", false},
+		{"corrupted body", valid[:10] + "Q" + valid[11:], false},
+		{"lookalike", "gh" + "p_" + strings.Repeat("a1", 18), false},
+		{"wrong length", valid[:len(valid)-2], false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValidTokenChecksum(tc.token); got != tc.want {
+				t.Errorf("ValidTokenChecksum(%q) = %v, want %v", tc.token, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInspectTokenChecksum(t *testing.T) {
+	valid := makeChecksumToken("gh"+"o_", strings.Repeat("Xy7", 10))
+
+	f := Finding{Type: "GitHub OAuth Access Token", Match: valid, Severity: "HIGH"}
+	inspectTokenChecksum(&f)
+	if f.Severity != "HIGH" || f.Confidence != "HIGH" {
+		t.Errorf("Valid token: severity=%s confidence=%s, want HIGH/HIGH", f.Severity, f.Confidence)
+	}
+
+	f = Finding{Type: "GitHub OAuth Access Token", Match: valid[:len(valid)-1] + "0", Severity: "HIGH"}
+	inspectTokenChecksum(&f)
+	if f.Severity != "LOW" || f.Confidence != "LOW" {
+		t.Errorf("Invalid token: severity=%s confidence=%s, want LOW/LOW", f.Severity, f.Confidence)
+	}
+}
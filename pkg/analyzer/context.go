@@ -0,0 +1,196 @@
+package analyzer
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// RedactionMask replaces secret values in context snippets.
+const RedactionMask = "[REDACTED]"
+
+// ContextLine is one line of source shown around a finding.
+type ContextLine struct {
+	LineNumber int
+	Text       string
+	InSpan     bool // the line is part of the finding itself
+}
+
+// byteRange is a half-open range of bytes on one line.
+type byteRange struct {
+	from, to int
+}
+
+// attachContext fills Finding.Context with up to n lines before and after
+// each finding. Every secret found in the file is redacted, not just the
+// finding's own, so neighbouring findings never leak through a snippet.
+func attachContext(lines []string, findings []Finding, n int) {
+	redactions := secretRanges(lines, findings)
+
+	for i := range findings {
+		f := &findings[i]
+		first := max(1, f.LineNumber-n)
+		last := min(len(lines), max(f.EndLine, f.LineNumber)+n)
+
+		f.Context = make([]ContextLine, 0, last-first+1)
+		for line := first; line <= last; line++ {
+			f.Context = append(f.Context, ContextLine{
+				LineNumber: line,
+				Text:       redactLine(lines[line-1], redactions[line]),
+				InSpan:     line >= f.LineNumber && line <= max(f.EndLine, f.LineNumber),
+			})
+		}
+	}
+}
+
+// secretRanges maps line numbers to the byte ranges that hold secrets.
+// Composite findings redact their component values rather than the whole
+// block of lines they span. A range that stops inside a token is extended
+// to the token's ends, so a rule that matches only part of a longer token
+// does not leave the rest of it readable.
+func secretRanges(lines []string, findings []Finding) map[int][]byteRange {
+	ranges := make(map[int][]byteRange)
+	add := func(line, from, to int) {
+		if line < 1 || line > len(lines) {
+			return
+		}
+		text := lines[line-1]
+		from, to = max(0, from), min(len(text), to)
+		if from < to {
+			for from > 0 && isTokenByte(text[from-1]) && isTokenByte(text[from]) {
+				from--
+			}
+			for to < len(text) && isTokenByte(text[to-1]) && isTokenByte(text[to]) {
+				to++
+			}
+			ranges[line] = append(ranges[line], byteRange{from, to})
+		}
+	}
+
+	for _, f := range findings {
+		if len(f.Related) > 0 {
+			for _, rel := range f.Related {
+				if rel.LineNumber < 1 || rel.LineNumber > len(lines) || rel.Match == "" {
+					continue
+				}
+				if at := strings.Index(lines[rel.LineNumber-1], rel.Match); at >= 0 {
+					add(rel.LineNumber, at, at+len(rel.Match))
+				}
+			}
+			continue
+		}
+
+		endLine := max(f.EndLine, f.LineNumber)
+		for line := f.LineNumber; line <= endLine; line++ {
+			from, to := 0, len(lines[min(line, len(lines))-1])
+			if line == f.LineNumber {
+				from = f.Column
+			}
+			if line == endLine {
+				to = f.EndColumn
+			}
+			add(line, from, to)
+		}
+	}
+
+	return ranges
+}
+
+// isTokenByte reports whether b can be part of a key or token.
+func isTokenByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || b == '_' || b == '-'
+}
+
+// redactLine replaces the given ranges of line with RedactionMask,
+// merging ranges that overlap.
+func redactLine(line string, ranges []byteRange) string {
+	if len(ranges) == 0 {
+		return line
+	}
+
+	sorted := append([]byteRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].from < sorted[j].from })
+
+	var sb strings.Builder
+	pos := 0
+	for _, r := range sorted {
+		if r.from < pos {
+			// Overlaps the range just masked; extend it.
+			pos = max(pos, r.to)
+			continue
+		}
+		sb.WriteString(line[pos:r.from])
+		sb.WriteString(RedactionMask)
+		pos = r.to
+	}
+	sb.WriteString(line[pos:])
+	return sb.String()
+}
+
+// Span is a half-open range of bytes in a text that holds a secret.
+type Span struct {
+	From, To int
+}
+
+// SecretSpans returns the byte ranges of text that hold secrets, sorted by
+// offset and with overlapping ranges merged, and the number of findings they belong to. name scopes the rules
+// by file type, as in AnalyzeBytes. Spans never include line terminators.
+func SecretSpans(ctx context.Context, name, text string, opts Options) ([]Span, int, error) {
+	opts.ContextLines = 0
+	findings, err := AnalyzeBytes(ctx, name, []byte(text), opts)
+	if err != nil || len(findings) == 0 {
+		return nil, 0, err
+	}
+
+	parts := strings.Split(text, "\n")
+	lines := make([]string, len(parts))
+	starts := make([]int, len(parts))
+	offset := 0
+	for i, part := range parts {
+		lines[i] = strings.TrimSuffix(part, "\r")
+		starts[i] = offset
+		offset += len(part) + 1
+	}
+
+	var spans []Span
+	for line, rs := range secretRanges(lines, findings) {
+		for _, r := range rs {
+			spans = append(spans, Span{starts[line-1] + r.from, starts[line-1] + r.to})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].From < spans[j].From })
+
+	// Merge overlapping spans, so that the union of every candidate is masked
+	// and each masked region is reported once.
+	union := spans[:0]
+	for _, s := range spans {
+		if n := len(union); n > 0 && s.From <= union[n-1].To {
+			union[n-1].To = max(union[n-1].To, s.To)
+			continue
+		}
+		union = append(union, s)
+	}
+	return union, len(findings), nil
+}
+
+// MaskSpans replaces the given spans of text with RedactionMask, merging
+// spans that overlap.
+func MaskSpans(text string, spans []Span) string {
+	ranges := make([]byteRange, len(spans))
+	for i, s := range spans {
+		ranges[i] = byteRange{s.From, s.To}
+	}
+	return redactLine(text, ranges)
+}
+
+// Redact replaces every secret the analyzer finds in text with
+// RedactionMask, keeping line terminators intact. name scopes the rules by
+// file type, as in AnalyzeBytes. It returns the redacted text and the
+// number of findings masked.
+func Redact(ctx context.Context, name, text string, opts Options) (string, int, error) {
+	spans, n, err := SecretSpans(ctx, name, text, opts)
+	if err != nil || n == 0 {
+		return text, 0, err
+	}
+	return MaskSpans(text, spans), n, nil
+}
@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Context Snippets
+// Tests that context lines are captured around findings with secrets redacted
+// =============================================================================
+
+func TestRedactLine(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		ranges []byteRange
+		want   string
+	}{
+		{"no ranges", "plain text", nil, "plain text"},
+		{"single range", "key=abcdef;", []byteRange{{4, 10}}, "key=" + RedactionMask + ";"},
+		{"overlapping ranges", "a=123456789", []byteRange{{2, 7}, {5, 11}}, "a=" + RedactionMask},
+		{"two ranges", "x=AAA y=BBB", []byteRange{{8, 11}, {2, 5}}, "x=" + RedactionMask + " y=" + RedactionMask},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := redactLine(tc.line, tc.ranges); got != tc.want {
+				t.Errorf("redactLine() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFileAttachesContext(t *testing.T) {
+	gitlab := fakeToken("glpat-", charsAlnum, 20)
+	npm := "npm_" + fakeToken("", charsAlnum, 36)
+	path := writeTestFile(t, "deploy.env", "# deploy settings\n"+
+		"REGION=eu-west-1\n"+
+		"GITLAB_TOKEN="+gitlab+"\n"+
+		"NPM_TOKEN="+npm+"\n"+
+		"DEBUG=false\n")
+
+	opts := DefaultOptions()
+	opts.ContextLines = 1
+	findings := findingsOfType(AnalyzeFileWithOptions(path, opts), "GitLab Personal Access Token")
+	if len(findings) != 1 {
+		t.Fatalf("Expected one GitLab finding, got %+v", findings)
+	}
+
+	context := findings[0].Context
+	if len(context) != 3 || context[0].LineNumber != 2 || context[2].LineNumber != 4 {
+		t.Fatalf("Expected lines 2-4 of context, got %+v", context)
+	}
+	if !context[1].InSpan || context[0].InSpan || context[2].InSpan {
+		t.Errorf("Only the finding's own line should be marked InSpan: %+v", context)
+	}
+	if context[1].Text != "GITLAB_TOKEN="+RedactionMask {
+		t.Errorf("Finding line = %q, want the token redacted", context[1].Text)
+	}
+	for _, line := range context {
+		if strings.Contains(line.Text, gitlab) || strings.Contains(line.Text, npm) {
+			t.Errorf("Context line %d leaks a secret: %q", line.LineNumber, line.Text)
+		}
+	}
+
+	if plain := AnalyzeFile(path); len(plain) > 0 && plain[0].Context != nil {
+		t.Error("Context should only be captured when ContextLines is set")
+	}
+}
+
+func TestContextRedactsMultiLineBlocks(t *testing.T) {
+	lines := []string{"before", "-----BEGIN X-----", "c2VjcmV0", "-----END X-----", "after"}
+	findings := []Finding{{LineNumber: 2, EndLine: 4, Column: 0, EndColumn: 15}}
+
+	attachContext(lines, findings, 1)
+
+	want := []string{"before", RedactionMask, RedactionMask, RedactionMask, "after"}
+	if len(findings[0].Context) != len(want) {
+		t.Fatalf("Expected %d context lines, got %+v", len(want), findings[0].Context)
+	}
+	for i, line := range findings[0].Context {
+		if line.Text != want[i] {
+			t.Errorf("Line %d = %q, want %q", line.LineNumber, line.Text, want[i])
+		}
+	}
+}
+
+func TestContextRedactsCompositeComponents(t *testing.T) {
+	lines := []string{"host: db.internal", "user: app", "password: Sup3rS3cret"}
+	findings := []Finding{{
+		LineNumber: 1, EndLine: 3, Column: 0, EndColumn: len(lines[2]),
+		Related: []Location{{LineNumber: 3, Type: "Password", Match: "Sup3rS3cret"}},
+	}}
+
+	attachContext(lines, findings, 0)
+
+	got := findings[0].Context
+	if got[0].Text != lines[0] || got[1].Text != lines[1] {
+		t.Errorf("Non-secret component lines should stay readable: %+v", got)
+	}
+	if got[2].Text != "password: "+RedactionMask {
+		t.Errorf("Password line = %q, want the value redacted", got[2].Text)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	gitlab := fakeToken("glpat-", charsAlnum, 20)
+
+	tests := []struct {
+		name      string
+		text      string
+		want      string
+		wantCount int
+	}{
+		{"clean text", "build ok in 3.2s\n", "build ok in 3.2s\n", 0},
+		{"token in log line", "pushing with " + gitlab + " now", "pushing with " + RedactionMask + " now", 1},
+		{"crlf kept", "a\r\nGITLAB_TOKEN=" + gitlab + "\r\n", "a\r\nGITLAB_TOKEN=" + RedactionMask + "\r\n", 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, count, err := Redact(context.Background(), "build.log", tc.text, DefaultOptions())
+			if err != nil {
+				t.Fatalf("Redact failed: %v", err)
+			}
+			if got != tc.want || count != tc.wantCount {
+				t.Errorf("Redact() = %q, %d; want %q, %d", got, count, tc.want, tc.wantCount)
+			}
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CorrelationWindow is the number of lines on either side of a finding that
+// are searched for the other parts of the same credential.
+const CorrelationWindow = 5
+
+var (
+	awsQuotedSecretPattern = regexp.MustCompile(`['"]([0-9a-zA-Z/+]{40})['"]`)
+	awsSecretValuePattern  = regexp.MustCompile(`(?i)secret[\w.-]{0,30}['"]?\s*[:=]\s*['"]?([0-9a-zA-Z/+]{40})(?:['"\s]|$)`)
+
+	dbHostPattern     = regexp.MustCompile(`(?i)\b(?:[a-z]+_)?(?:host|hostname|server)['"]?\s*[:=]\s*['"]?([A-Za-z0-9.\-]+(?::\d+)?)`)
+	dbUserPattern     = regexp.MustCompile(`(?i)\b(?:[a-z]+_)?(?:user|username|login)['"]?\s*[:=]\s*['"]?([^\s'",;]+)`)
+	dbPasswordPattern = regexp.MustCompile(`(?i)\b(?:[a-z]+_)?(?:password|passwd|pwd|pass)['"]?\s*[:=]\s*(['"]?)([^\s'",;]+)`)
+	dbContextPattern  = regexp.MustCompile(`(?i)(db|database|postgres|mysql|mongo|redis|sql|jdbc)`)
+
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][\w.]*$`)
+)
+
+// codeExtensions are source files, where an unquoted value assigned to a
+// password is an expression such as a variable rather than the password.
+var codeExtensions = map[string]bool{
+	".go": true, ".py": true, ".js": true, ".jsx": true, ".ts": true, ".tsx": true,
+	".java": true, ".kt": true, ".scala": true, ".rb": true, ".php": true, ".cs": true,
+	".rs": true, ".swift": true, ".c": true, ".cc": true, ".cpp": true, ".h": true,
+}
+
+// correlateFindings pairs findings and nearby context lines that together
+// form one credential and replaces them with a single composite finding.
+// The components are recorded in Related. Details holds the values that are
+// not secret, such as the host and username, so that reports and sinks that
+// print Details never repeat the password or secret key.
+func correlateFindings(filePath string, lines []string, findings []Finding, opts Options) []Finding {
+	absorbed := make(map[int]bool)
+	composites := make([]Finding, 0)
+
+	composites = append(composites, pairAWSCredentials(filePath, lines, findings, absorbed)...)
+	if opts.ProviderEnabled(ProviderDatabase) {
+		composites = append(composites, groupDatabaseCredentials(filePath, lines, findings, absorbed)...)
+	}
+
+	if len(composites) == 0 {
+		return findings
+	}
+
+	result := make([]Finding, 0, len(findings)+len(composites))
+	for i, f := range findings {
+		if !absorbed[i] {
+			result = append(result, f)
+		}
+	}
+	result = append(result, composites...)
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].LineNumber != result[j].LineNumber {
+			return result[i].LineNumber < result[j].LineNumber
+		}
+		return result[i].Column < result[j].Column
+	})
+	return result
+}
+
+// pairAWSCredentials matches each AWS access key ID with a secret access key
+// found within CorrelationWindow lines, either as its own finding or as a
+// "secret = ..." assignment the line rules do not catch on their own.
+func pairAWSCredentials(filePath string, lines []string, findings []Finding, absorbed map[int]bool) []Finding {
+	composites := make([]Finding, 0)
+
+	for i, keyFinding := range findings {
+		if keyFinding.Type != "AWS Access Key ID" || absorbed[i] {
+			continue
+		}
+
+		secret, secretLine := "", 0
+		for j, candidate := range findings {
+			if candidate.Type != "AWS Secret Access Key" || absorbed[j] || !withinWindow(keyFinding.LineNumber, candidate.LineNumber) {
+				continue
+			}
+			if m := awsQuotedSecretPattern.FindStringSubmatch(candidate.Match); m != nil {
+				secret, secretLine = m[1], candidate.LineNumber
+				break
+			}
+		}
+		if secret == "" {
+			secret, secretLine = searchWindow(lines, keyFinding.LineNumber, awsSecretValuePattern)
+		}
+		if secret == "" || isPlaceholderValue(secret) {
+			continue
+		}
+
+		absorbed[i] = true
+		absorbCovering(findings, absorbed, secretLine, secret)
+
+		composites = append(composites, spanRelated(lines, Finding{
+			FilePath:   filePath,
+			LineNumber: keyFinding.LineNumber,
+			Column:     keyFinding.Column,
+			EndColumn:  keyFinding.EndColumn,
+			Type:       "AWS Credential Pair",
+			Match:      keyFinding.Match,
+			Severity:   "HIGH",
+			Confidence: "HIGH",
+			Details: map[string]string{
+				"access_key_id": keyFinding.Match,
+			},
+			Related: []Location{
+				{LineNumber: keyFinding.LineNumber, Type: "AWS Access Key ID", Match: keyFinding.Match},
+				{LineNumber: secretLine, Type: "AWS Secret Access Key", Match: secret},
+			},
+			specificity: specificityProvider,
+		}))
+	}
+
+	return composites
+}
+
+// groupDatabaseCredentials looks for a password assignment with a host and
+// username assigned within CorrelationWindow lines, as found in .env files
+// and YAML/INI database sections.
+func groupDatabaseCredentials(filePath string, lines []string, findings []Finding, absorbed map[int]bool) []Finding {
+	composites := make([]Finding, 0)
+
+	for idx, line := range lines {
+		m := dbPasswordPattern.FindStringSubmatchIndex(line)
+		if m == nil {
+			continue
+		}
+		lineNumber := idx + 1
+		password := line[m[4]:m[5]]
+		quoted := m[3] > m[2]
+		if isPlaceholderValue(password) || (!quoted && isPasswordExpression(filePath, password)) {
+			continue
+		}
+
+		host, hostLine := searchWindow(lines, lineNumber, dbHostPattern)
+		user, userLine := searchWindow(lines, lineNumber, dbUserPattern)
+		if host == "" || user == "" || isPlaceholderValue(user) {
+			continue
+		}
+
+		findingType := "Host Credential Set"
+		if windowMatches(lines, lineNumber, dbContextPattern) {
+			findingType = "Database Credential"
+		}
+
+		absorbCovering(findings, absorbed, lineNumber, password)
+
+		composites = append(composites, spanRelated(lines, Finding{
+			FilePath:   filePath,
+			LineNumber: lineNumber,
+			Column:     m[4],
+			EndColumn:  m[5],
+			Type:       findingType,
+			Match:      password,
+			Severity:   "HIGH",
+			Confidence: "MEDIUM",
+			Details: map[string]string{
+				"host":     host,
+				"username": user,
+			},
+			Related: []Location{
+				{LineNumber: hostLine, Type: "Host", Match: host},
+				{LineNumber: userLine, Type: "Username", Match: user},
+				{LineNumber: lineNumber, Type: "Password", Match: password},
+			},
+			specificity: specificityProvider,
+		}))
+	}
+
+	return composites
+}
+
+// spanRelated widens a composite finding to cover the lines of all its
+// components. When the components sit on different lines, whole lines are
+// covered.
+func spanRelated(lines []string, f Finding) Finding {
+	first, last := f.LineNumber, f.LineNumber
+	for _, rel := range f.Related {
+		first, last = min(first, rel.LineNumber), max(last, rel.LineNumber)
+	}
+	if first == last {
+		return f
+	}
+
+	f.LineNumber, f.Column = first, 0
+	f.EndLine, f.EndColumn = last, len(lines[last-1])
+	return f
+}
+
+func withinWindow(a, b int) bool {
+	d := a - b
+	if d < 0 {
+		d = -d
+	}
+	return d <= CorrelationWindow
+}
+
+// searchWindow returns the first capture of pattern on the lines around
+// lineNumber (1-based), checking nearer lines first.
+func searchWindow(lines []string, lineNumber int, pattern *regexp.Regexp) (string, int) {
+	for distance := 0; distance <= CorrelationWindow; distance++ {
+		for _, n := range []int{lineNumber - distance, lineNumber + distance} {
+			if n < 1 || n > len(lines) || (distance == 0 && n != lineNumber) {
+				continue
+			}
+			if m := pattern.FindStringSubmatch(lines[n-1]); m != nil {
+				return m[1], n
+			}
+		}
+	}
+	return "", 0
+}
+
+// isPasswordExpression reports whether an unquoted value assigned to a
+// password is code, such as os.Getenv("DB_PASSWORD") or cfg.Password,
+// rather than a literal password.
+func isPasswordExpression(filePath, value string) bool {
+	if strings.Contains(value, "(") {
+		return true
+	}
+	return codeExtensions[strings.ToLower(filepath.Ext(filePath))] && identifierPattern.MatchString(value)
+}
+
+func windowMatches(lines []string, lineNumber int, pattern *regexp.Regexp) bool {
+	for n := lineNumber - CorrelationWindow; n <= lineNumber+CorrelationWindow; n++ {
+		if n >= 1 && n <= len(lines) && pattern.MatchString(lines[n-1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// absorbCovering marks findings on lineNumber that contain value as part of
+// a composite so the same secret is not reported twice.
+func absorbCovering(findings []Finding, absorbed map[int]bool, lineNumber int, value string) {
+	for i, f := range findings {
+		if f.LineNumber == lineNumber && strings.Contains(f.Match, value) {
+			absorbed[i] = true
+		}
+	}
+}
+
+// isPlaceholderValue reports whether a credential value is obviously a
+// template variable or stand-in rather than a real secret.
+func isPlaceholderValue(value string) bool {
+	lowered := strings.ToLower(strings.Trim(value, `'"`))
+	if lowered == "" {
+		return true
+	}
+
+	for _, prefix := range []string{"$", "<", "{{", "%", "["} {
+		if strings.HasPrefix(lowered, prefix) {
+			return true
+		}
+	}
+
+	switch lowered {
+	case "password", "passwd", "secret", "none", "null", "dummy", "example", "test":
+		return true
+	}
+
+	for _, marker := range []string{"your_", "your-", "xxx", "***", "changeme", "change_me", "placeholder", "redacted", "example"} {
+		if strings.Contains(lowered, marker) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,360 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MinGoSecretEntropy is the Shannon entropy a string literal assigned to a
+// sensitive identifier needs before it is reported.
+const MinGoSecretEntropy = 3.0
+
+var (
+	sensitiveIdentPattern    = regexp.MustCompile(`(?i)(passw(?:or)?d|pwd|secret|token|api_?key|access_?key|private_?key|signing_?key|credential)`)
+	nonSecretIdentSuffix     = regexp.MustCompile(`(?i)(url|uri|path|file|dir|env|name|prefix|suffix|type|field|header|len|length|count|regex|pattern|format|id)$`)
+	envVarNamePattern        = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+	dsnKeyValuePasswordRegex = regexp.MustCompile(`(?i)(?:^|[\s;])(?:password|pwd)\s*=\s*'?([^\s;']+)`)
+	dsnUserinfoRegex         = regexp.MustCompile(`^[^:@/\s]+:([^@\s]+)@`)
+)
+
+// credentialArg describes a call argument that carries a credential.
+type credentialArg struct {
+	index int
+	dsn   bool // the argument is a connection string, not a bare secret
+}
+
+// credentialCalls maps "pkg.Func" (or a bare method name) to the argument
+// that carries a credential.
+var credentialCalls = map[string]credentialArg{
+	"sql.Open":         {index: 1, dsn: true},
+	"sqlx.Open":        {index: 1, dsn: true},
+	"sqlx.Connect":     {index: 1, dsn: true},
+	"gorm.Open":        {index: 1, dsn: true},
+	"pgx.Connect":      {index: 1, dsn: true},
+	"pgxpool.New":      {index: 1, dsn: true},
+	"smtp.PlainAuth":   {index: 2},
+	"url.UserPassword": {index: 1},
+	"SetBasicAuth":     {index: 1},
+}
+
+// inspectGoSource parses a Go file and reports string literals that are
+// assigned to sensitive identifiers or passed as credential arguments.
+// Literals joined with + and references to package-level string constants
+// are folded into a single value. Details records the enclosing function.
+func inspectGoSource(filePath string, lines []string) []Finding {
+	if strings.ToLower(filepath.Ext(filePath)) != ".go" {
+		return nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, strings.Join(lines, "\n"), parser.SkipObjectResolution)
+	if err != nil {
+		return nil
+	}
+
+	g := &goInspector{
+		filePath: filePath,
+		fset:     fset,
+		consts:   packageConstants(file),
+		findings: make([]Finding, 0),
+	}
+
+	for _, decl := range file.Decls {
+		function := ""
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			function = funcName(fn)
+		}
+		ast.Inspect(decl, func(n ast.Node) bool {
+			g.inspectNode(n, function)
+			return true
+		})
+	}
+
+	return g.findings
+}
+
+type goInspector struct {
+	filePath string
+	fset     *token.FileSet
+	consts   map[string]ast.Expr
+	findings []Finding
+}
+
+func (g *goInspector) inspectNode(n ast.Node, function string) {
+	switch node := n.(type) {
+	case *ast.ValueSpec:
+		for i, name := range node.Names {
+			if i < len(node.Values) {
+				g.checkAssignment(name.Name, node.Values[i], function)
+			}
+		}
+
+	case *ast.AssignStmt:
+		if len(node.Lhs) != len(node.Rhs) {
+			return
+		}
+		for i, lhs := range node.Lhs {
+			g.checkAssignment(exprName(lhs), node.Rhs[i], function)
+		}
+
+	case *ast.KeyValueExpr:
+		g.checkAssignment(exprName(node.Key), node.Value, function)
+
+	case *ast.CallExpr:
+		g.checkCall(node, function)
+	}
+}
+
+// checkAssignment reports value if name looks like it holds a secret and
+// value folds to a string that looks like one.
+func (g *goInspector) checkAssignment(name string, value ast.Expr, function string) {
+	if !isSensitiveIdent(name) {
+		return
+	}
+	s, ok := g.fold(value, 0)
+	if !ok || !looksLikeHardcodedSecret(s) {
+		return
+	}
+
+	g.report(value, s, "Go Hardcoded Secret", "MEDIUM", function, map[string]string{"identifier": name})
+}
+
+func (g *goInspector) checkCall(call *ast.CallExpr, function string) {
+	name := callName(call)
+
+	// os.Setenv("API_TOKEN", "...") names the secret in its first argument.
+	if name == "os.Setenv" && len(call.Args) == 2 {
+		if key, ok := g.fold(call.Args[0], 0); ok {
+			g.checkAssignment(key, call.Args[1], function)
+		}
+		return
+	}
+
+	arg, ok := credentialCalls[name]
+	if !ok {
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			arg, ok = credentialCalls[name[i+1:]]
+		}
+	}
+	if !ok || arg.index >= len(call.Args) {
+		return
+	}
+
+	value, ok := g.fold(call.Args[arg.index], 0)
+	if !ok {
+		return
+	}
+
+	secret := value
+	if arg.dsn {
+		secret = dsnPassword(value)
+	}
+	if secret == "" || isPlaceholderValue(secret) {
+		return
+	}
+
+	g.report(call.Args[arg.index], value, "Go Hardcoded Credential", "HIGH", function, map[string]string{"call": name})
+}
+
+func (g *goInspector) report(expr ast.Expr, value, findingType, severity, function string, details map[string]string) {
+	start := g.fset.Position(expr.Pos())
+	end := g.fset.Position(expr.End())
+
+	if function != "" {
+		details["function"] = function
+	}
+
+	g.findings = append(g.findings, Finding{
+		FilePath:    g.filePath,
+		LineNumber:  start.Line,
+		EndLine:     end.Line,
+		Column:      start.Column - 1,
+		EndColumn:   end.Column - 1,
+		Type:        findingType,
+		Match:       value,
+		Severity:    severity,
+		Confidence:  "HIGH",
+		Details:     details,
+		specificity: specificityContext,
+	})
+}
+
+// fold evaluates string literals, + concatenations and references to
+// package-level string constants.
+func (g *goInspector) fold(expr ast.Expr, depth int) (string, bool) {
+	if depth > 16 {
+		return "", false
+	}
+
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		s, err := strconv.Unquote(e.Value)
+		return s, err == nil
+	case *ast.ParenExpr:
+		return g.fold(e.X, depth+1)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := g.fold(e.X, depth+1)
+		if !ok {
+			return "", false
+		}
+		right, ok := g.fold(e.Y, depth+1)
+		return left + right, ok
+	case *ast.Ident:
+		if value, ok := g.consts[e.Name]; ok {
+			return g.fold(value, depth+1)
+		}
+	}
+	return "", false
+}
+
+// packageConstants maps the names of package-level constants to their
+// value expressions.
+func packageConstants(file *ast.File) map[string]ast.Expr {
+	consts := make(map[string]ast.Expr)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i < len(vs.Values) {
+					consts[name.Name] = vs.Values[i]
+				}
+			}
+		}
+	}
+	return consts
+}
+
+// funcName returns "Func" or "Type.Method" for a function declaration.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+
+	recv := fn.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv = r.X
+	case *ast.IndexListExpr:
+		recv = r.X
+	}
+	if ident, ok := recv.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// callName returns "pkg.Func", "recv.Method" or "Func" for a call.
+func callName(call *ast.CallExpr) string {
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		if x, ok := fn.X.(*ast.Ident); ok {
+			return x.Name + "." + fn.Sel.Name
+		}
+		return fn.Sel.Name
+	}
+	return ""
+}
+
+// exprName returns the identifier an assignment or composite literal key
+// refers to: a variable, a field, or a string map key.
+func exprName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.StarExpr:
+		return exprName(e.X)
+	case *ast.IndexExpr:
+		if lit, ok := e.Index.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			s, _ := strconv.Unquote(lit.Value)
+			return s
+		}
+	case *ast.BasicLit:
+		if e.Kind == token.STRING {
+			s, _ := strconv.Unquote(e.Value)
+			return s
+		}
+	}
+	return ""
+}
+
+func isSensitiveIdent(name string) bool {
+	return sensitiveIdentPattern.MatchString(name) && !nonSecretIdentSuffix.MatchString(name)
+}
+
+// looksLikeHardcodedSecret filters out messages, environment variable
+// names, placeholders and other low-entropy strings.
+func looksLikeHardcodedSecret(s string) bool {
+	if len(s) < 8 || strings.ContainsAny(s, " \t\n") {
+		return false
+	}
+	if envVarNamePattern.MatchString(s) || isPlaceholderValue(s) || looksLikeFalsePositive(s) {
+		return false
+	}
+	return CalculateEntropy(s) >= MinGoSecretEntropy
+}
+
+// dsnPassword extracts the password from a URL, key=value or
+// user:password@host style connection string.
+func dsnPassword(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			return password
+		}
+	}
+	if m := dsnKeyValuePasswordRegex.FindStringSubmatch(dsn); m != nil {
+		return m[1]
+	}
+	if m := dsnUserinfoRegex.FindStringSubmatch(dsn); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// mergeLineFindings adds extra findings and resolves overlaps line by line
+// with mergeOverlapping.
+func mergeLineFindings(findings, extra []Finding) []Finding {
+	if len(extra) == 0 {
+		return findings
+	}
+
+	byLine := make(map[int][]Finding)
+	for _, f := range append(findings, extra...) {
+		byLine[f.LineNumber] = append(byLine[f.LineNumber], f)
+	}
+
+	lineNumbers := make([]int, 0, len(byLine))
+	for n := range byLine {
+		lineNumbers = append(lineNumbers, n)
+	}
+	sort.Ints(lineNumbers)
+
+	merged := make([]Finding, 0, len(findings)+len(extra))
+	for _, n := range lineNumbers {
+		merged = append(merged, mergeOverlapping(byLine[n])...)
+	}
+	return merged
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import "testing"
+
+// =============================================================================
+// TEST: Go Source Analysis
+// Tests identifier and call context detection in Go files
+// =============================================================================
+
+const goSourceFixture = `package main
+
+import (
+	"database/sql"
+	"net/smtp"
+	"os"
+)
+
+const apiSecret = "q8Zr2Lx0Vt7Nw4Kp"
+
+const dsnPrefix = "app:Pr0dDbPa55"
+
+type Config struct {
+	Password string
+	Host     string
+}
+
+func connect() {
+	db, _ := sql.Open("mysql", dsnPrefix+"@tcp(db.internal:3306)/app")
+	_ = db
+}
+
+func (c *Config) mail() {
+	auth := smtp.PlainAuth("", "ops@corp.net", "M4ilR3layK3y!", "smtp.corp.net")
+	_ = auth
+}
+
+func settings() Config {
+	os.Setenv("SERVICE_TOKEN", "tk_" + "9fQ2mX7pL4sV1bN8")
+	return Config{Password: "Zx9!mQ2#vL7wKp", Host: "db.internal"}
+}
+
+func harmless() {
+	passwordEnv := "DB_PASSWORD"
+	tokenURL := "https://auth.internal/oauth/token"
+	errMsg := "invalid password supplied"
+	secret := os.Getenv("APP_SECRET")
+	placeholder := "<your-api-key>"
+	_, _, _, _, _ = passwordEnv, tokenURL, errMsg, secret, placeholder
+}
+`
+
+func TestInspectGoSource(t *testing.T) {
+	path := writeTestFile(t, "main.go", goSourceFixture)
+	findings := AnalyzeFile(path)
+
+	tests := []struct {
+		name         string
+		findingType  string
+		line         int
+		wantMatch    string
+		wantFunction string
+		detailKey    string
+		detailValue  string
+	}{
+		{"package constant", "Go Hardcoded Secret", 9, "q8Zr2Lx0Vt7Nw4Kp", "", "identifier", "apiSecret"},
+		{"sql.Open DSN from constant", "Go Hardcoded Credential", 19, "app:Pr0dDbPa55@tcp(db.internal:3306)/app", "connect", "call", "sql.Open"},
+		{"smtp.PlainAuth password", "Go Hardcoded Credential", 24, "M4ilR3layK3y!", "Config.mail", "call", "smtp.PlainAuth"},
+		{"os.Setenv concatenation", "Go Hardcoded Secret", 29, "tk_9fQ2mX7pL4sV1bN8", "settings", "identifier", "SERVICE_TOKEN"},
+		{"struct field", "Go Hardcoded Secret", 30, "Zx9!mQ2#vL7wKp", "settings", "identifier", "Password"},
+	}
+
+	goFindings := append(findingsOfType(findings, "Go Hardcoded Secret"), findingsOfType(findings, "Go Hardcoded Credential")...)
+	if len(goFindings) != len(tests) {
+		t.Errorf("Expected %d Go findings, got %d: %+v", len(tests), len(goFindings), goFindings)
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var got *Finding
+			for i := range goFindings {
+				if goFindings[i].LineNumber == tc.line && goFindings[i].Type == tc.findingType {
+					got = &goFindings[i]
+				}
+			}
+			if got == nil {
+				t.Fatalf("No %q finding on line %d", tc.findingType, tc.line)
+			}
+			if got.Match != tc.wantMatch {
+				t.Errorf("Match = %q, want %q", got.Match, tc.wantMatch)
+			}
+			if got.Details["function"] != tc.wantFunction {
+				t.Errorf("function = %q, want %q", got.Details["function"], tc.wantFunction)
+			}
+			if got.Details[tc.detailKey] != tc.detailValue {
+				t.Errorf("%s = %q, want %q", tc.detailKey, got.Details[tc.detailKey], tc.detailValue)
+			}
+		})
+	}
+}
+
+func TestInspectGoSourceSkipsOtherFiles(t *testing.T) {
+	path := writeTestFile(t, "main.txt", goSourceFixture)
+	if len(inspectGoSource(path, []string{goSourceFixture})) != 0 {
+		t.Error("Go analysis should only run on .go files")
+	}
+
+	broken := writeTestFile(t, "broken.go", "package main\nfunc {\n")
+	if len(inspectGoSource(broken, []string{"package main", "func {"})) != 0 {
+		t.Error("Unparseable Go files should produce no AST findings")
+	}
+}
+
+func TestGoFindingsBeatGenericRules(t *testing.T) {
+	path := writeTestFile(t, "config.go", "package config\n\nvar dbPassword = \"Zx9!mQ2#vL7wKp\"\n")
+
+	findings := AnalyzeFile(path)
+	if len(findings) != 1 {
+		t.Fatalf("Expected one merged finding, got %+v", findings)
+	}
+	if findings[0].Type != "Go Hardcoded Secret" {
+		t.Errorf("Type = %q, want Go Hardcoded Secret", findings[0].Type)
+	}
+
+	disabled := Options{DisabledProviders: []string{ProviderGo}}
+	if len(findingsOfType(AnalyzeFileWithOptions(path, disabled), "Go Hardcoded Secret")) != 0 {
+		t.Error("Expected no Go findings when the go provider is disabled")
+	}
+}
@@ -0,0 +1,117 @@
+package analyzer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LongLivedJWT is the lifetime above which a token is flagged as long-lived.
+const LongLivedJWT = 30 * 24 * time.Hour
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type jwtClaims struct {
+	Iss string          `json:"iss"`
+	Sub string          `json:"sub"`
+	Aud json.RawMessage `json:"aud"`
+	Exp *float64        `json:"exp"`
+	Iat *float64        `json:"iat"`
+}
+
+// inspectJWT decodes the header and payload of a JSON Web Token, records
+// its claims in Details and adjusts severity: unsigned ("alg": "none") and
+// non-expiring or long-lived tokens are HIGH, expired tokens are LOW.
+// Matches that do not decode as JSON are dropped.
+func inspectJWT(f *Finding) bool {
+	parts := strings.Split(f.Match, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil || header.Alg == "" {
+		return false
+	}
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return false
+	}
+
+	details := map[string]string{"algorithm": header.Alg}
+	if claims.Iss != "" {
+		details["issuer"] = claims.Iss
+	}
+	if claims.Sub != "" {
+		details["subject"] = claims.Sub
+	}
+	if aud := parseAudience(claims.Aud); aud != "" {
+		details["audience"] = aud
+	}
+
+	var warnings []string
+	f.Confidence = "HIGH"
+
+	if strings.EqualFold(header.Alg, "none") {
+		warnings = append(warnings, "unsigned token (alg: none)")
+		f.Severity = "HIGH"
+	}
+
+	if claims.Exp == nil {
+		warnings = append(warnings, "no expiry")
+		f.Severity = "HIGH"
+	} else {
+		exp := time.Unix(int64(*claims.Exp), 0).UTC()
+		details["expires"] = exp.Format(time.RFC3339)
+
+		issued := now()
+		if claims.Iat != nil {
+			issued = time.Unix(int64(*claims.Iat), 0).UTC()
+			details["issued"] = issued.Format(time.RFC3339)
+		}
+
+		switch {
+		case exp.Before(now()):
+			warnings = append(warnings, "expired")
+			f.Severity = "LOW"
+		case exp.Sub(issued) > LongLivedJWT:
+			warnings = append(warnings, fmt.Sprintf("long-lived (%d days)", int(exp.Sub(issued).Hours()/24)))
+			f.Severity = "HIGH"
+		}
+	}
+
+	if len(warnings) > 0 {
+		details["warnings"] = strings.Join(warnings, ", ")
+	}
+	f.Details = details
+	return true
+}
+
+func decodeJWTSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// parseAudience handles the "aud" claim, which may be a string or a list.
+func parseAudience(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		return single
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, ", ")
+	}
+	return ""
+}
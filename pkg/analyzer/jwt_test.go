@@ -0,0 +1,104 @@
+package analyzer
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// =============================================================================
+// TEST: JWT Inspection
+// Tokens are built at runtime from header and claims JSON
+// =============================================================================
+
+func makeJWT(header, claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(header)) + "." + enc.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+func TestInspectJWT(t *testing.T) {
+	fixed := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	hs256 := `{"alg":"HS256","typ":"JWT"}`
+	hour := fixed.Add(time.Hour).Unix()
+	iat := fixed.Unix()
+
+	tests := []struct {
+		name         string
+		token        string
+		wantSeverity string
+		wantWarning  string
+	}{
+		{
+			name:         "short-lived token",
+			token:        makeJWT(hs256, `{"iss":"auth.corp","sub":"user-1","aud":["api","web"],"iat":`+itoa(iat)+`,"exp":`+itoa(hour)+`}`),
+			wantSeverity: "MEDIUM",
+		},
+		{
+			name:         "unsigned token",
+			token:        makeJWT(`{"alg":"none"}`, `{"sub":"admin","exp":`+itoa(hour)+`}`),
+			wantSeverity: "HIGH",
+			wantWarning:  "alg: none",
+		},
+		{
+			name:         "non-expiring token",
+			token:        makeJWT(hs256, `{"sub":"svc"}`),
+			wantSeverity: "HIGH",
+			wantWarning:  "no expiry",
+		},
+		{
+			name:         "long-lived token",
+			token:        makeJWT(hs256, `{"iat":`+itoa(iat)+`,"exp":`+itoa(fixed.Add(365*24*time.Hour).Unix())+`}`),
+			wantSeverity: "HIGH",
+			wantWarning:  "long-lived",
+		},
+		{
+			name:         "expired token",
+			token:        makeJWT(hs256, `{"exp":`+itoa(fixed.Add(-time.Hour).Unix())+`}`),
+			wantSeverity: "LOW",
+			wantWarning:  "expired",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := Finding{Type: "JSON Web Token", Match: tc.token, Severity: "MEDIUM"}
+			if !inspectJWT(&f) {
+				t.Fatalf("inspectJWT dropped a valid token")
+			}
+			if f.Severity != tc.wantSeverity {
+				t.Errorf("Severity = %s, want %s", f.Severity, tc.wantSeverity)
+			}
+			if !strings.Contains(f.Details["warnings"], tc.wantWarning) {
+				t.Errorf("Warnings = %q, want to contain %q", f.Details["warnings"], tc.wantWarning)
+			}
+		})
+	}
+}
+
+func TestInspectJWTReportsClaims(t *testing.T) {
+	f := Finding{Match: makeJWT(`{"alg":"RS256"}`, `{"iss":"auth.corp","sub":"user-1","aud":["api","web"]}`)}
+	inspectJWT(&f)
+
+	want := map[string]string{"algorithm": "RS256", "issuer": "auth.corp", "subject": "user-1", "audience": "api, web"}
+	for k, v := range want {
+		if f.Details[k] != v {
+			t.Errorf("Details[%q] = %q, want %q", k, f.Details[k], v)
+		}
+	}
+}
+
+func TestInspectJWTDropsGarbage(t *testing.T) {
+	f := Finding{Match: "eyJub3QganNvbg.eyJhbHNvIG5vdA.sig"}
+	if inspectJWT(&f) {
+		t.Error("Expected undecodable token to be dropped")
+	}
+}
+
+func itoa(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
@@ -0,0 +1,334 @@
+package analyzer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// maxPEMLines bounds how far past a BEGIN line the END line is searched for.
+const maxPEMLines = 200
+
+// privateKeyTypes are the rule names whose findings mark the start of a PEM
+// private key block.
+var privateKeyTypes = map[string]bool{
+	"RSA Private Key":       true,
+	"SSH Private Key":       true,
+	"PGP Private Key":       true,
+	"EC Private Key":        true,
+	"DSA Private Key":       true,
+	"PKCS#8 Private Key":    true,
+	"Encrypted Private Key": true,
+}
+
+// keyInfo describes a parsed private key.
+type keyInfo struct {
+	keyType     string
+	bits        int
+	encrypted   bool
+	fingerprint string
+}
+
+// inspectPrivateKeys captures the full PEM block behind each private key
+// header finding and parses it. Parsed keys gain key type, size, encryption
+// status and public-key fingerprint in Details. Passphrase-protected keys
+// drop to MEDIUM; blocks that are truncated or fail to parse drop to LOW.
+func inspectPrivateKeys(lines []string, findings []Finding) []Finding {
+	for i := range findings {
+		f := &findings[i]
+		if !privateKeyTypes[f.Type] || f.LineNumber < 1 || f.LineNumber > len(lines) {
+			continue
+		}
+
+		block, endLine, endColumn := extractPEMBlock(lines, f.LineNumber-1, f.Column)
+		if block == "" {
+			markUnparsedKey(f, "truncated (no END line)")
+			continue
+		}
+		f.EndLine, f.EndColumn = endLine, endColumn
+
+		info, err := parsePrivateKeyBlock(block)
+		if err != nil {
+			markUnparsedKey(f, err.Error())
+			continue
+		}
+
+		f.Details = map[string]string{
+			"key_type":  info.keyType,
+			"encrypted": fmt.Sprintf("%t", info.encrypted),
+		}
+		if info.bits > 0 {
+			f.Details["bits"] = fmt.Sprintf("%d", info.bits)
+		}
+		if info.fingerprint != "" {
+			f.Details["fingerprint"] = info.fingerprint
+		}
+
+		if info.encrypted {
+			f.Severity = "MEDIUM"
+			f.Confidence = "HIGH"
+		} else {
+			f.Severity = "HIGH"
+			f.Confidence = "HIGH"
+		}
+	}
+
+	return findings
+}
+
+func markUnparsedKey(f *Finding, reason string) {
+	f.Severity = "LOW"
+	f.Confidence = "LOW"
+	f.Details = map[string]string{"status": reason}
+}
+
+// extractPEMBlock returns the PEM text starting at the BEGIN marker on
+// lines[start] and ending at the matching END marker. Keys embedded in JSON
+// or env files as a single line with literal "\n" escapes are unescaped.
+// It also returns the 1-based line and the byte offset just past the END
+// marker. It returns "" if no END marker is found.
+func extractPEMBlock(lines []string, start, column int) (string, int, int) {
+	first := lines[start]
+	if column > 0 && column < len(first) {
+		first = first[column:]
+	} else {
+		column = 0
+	}
+
+	if strings.Contains(first, `\n`) {
+		if end := strings.Index(first, "-----END "); end >= 0 {
+			if tail := strings.Index(first[end+9:], "-----"); tail >= 0 {
+				raw := first[:end+9+tail+5]
+				return strings.ReplaceAll(raw, `\n`, "\n"), start + 1, column + len(raw)
+			}
+		}
+		return "", 0, 0
+	}
+
+	var sb strings.Builder
+	for i := start; i < len(lines) && i < start+maxPEMLines; i++ {
+		line := strings.TrimSpace(lines[i])
+		if i == start {
+			line = strings.TrimSpace(first)
+		}
+		line = strings.Trim(line, `'",`)
+
+		sb.WriteString(line)
+		sb.WriteString("\n")
+		if strings.HasPrefix(line, "-----END ") {
+			endColumn := len(lines[i])
+			if marker := strings.Index(lines[i], line); marker >= 0 {
+				endColumn = marker + len(line)
+			}
+			return sb.String(), i + 1, endColumn
+		}
+	}
+	return "", 0, 0
+}
+
+// parsePrivateKeyBlock decodes a PEM private key block with the standard
+// crypto packages and describes it.
+func parsePrivateKeyBlock(text string) (keyInfo, error) {
+	block, _ := pem.Decode([]byte(text))
+	if block == nil {
+		return keyInfo{}, errors.New("malformed PEM block")
+	}
+
+	// Legacy OpenSSL encryption keeps the PKCS#1/SEC1 type but adds headers.
+	if strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+		return keyInfo{keyType: legacyKeyType(block.Type), encrypted: true}, nil
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return keyInfo{}, errors.New("invalid RSA key data")
+		}
+		return describePublicKey(&key.PublicKey)
+
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return keyInfo{}, errors.New("invalid EC key data")
+		}
+		return describePublicKey(&key.PublicKey)
+
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return keyInfo{}, errors.New("invalid PKCS#8 key data")
+		}
+		signer, ok := key.(interface{ Public() crypto.PublicKey })
+		if !ok {
+			return keyInfo{}, errors.New("unsupported PKCS#8 key")
+		}
+		return describePublicKey(signer.Public())
+
+	case "ENCRYPTED PRIVATE KEY":
+		var encrypted struct {
+			Algorithm asn1.RawValue
+			Data      []byte
+		}
+		if _, err := asn1.Unmarshal(block.Bytes, &encrypted); err != nil {
+			return keyInfo{}, errors.New("invalid encrypted PKCS#8 data")
+		}
+		return keyInfo{keyType: "unknown (PKCS#8)", encrypted: true}, nil
+
+	case "DSA PRIVATE KEY":
+		var key struct {
+			Version       int
+			P, Q, G, Y, X *big.Int
+		}
+		if _, err := asn1.Unmarshal(block.Bytes, &key); err != nil {
+			return keyInfo{}, errors.New("invalid DSA key data")
+		}
+		return keyInfo{keyType: "DSA", bits: key.P.BitLen()}, nil
+
+	case "OPENSSH PRIVATE KEY":
+		return parseOpenSSHKey(block.Bytes)
+
+	case "PGP PRIVATE KEY BLOCK":
+		// OpenPGP packets are not parsed by the standard library; a complete
+		// armored block is as much as can be confirmed.
+		return keyInfo{keyType: "PGP"}, nil
+	}
+
+	return keyInfo{}, fmt.Errorf("unsupported block type %q", block.Type)
+}
+
+func legacyKeyType(blockType string) string {
+	switch blockType {
+	case "RSA PRIVATE KEY":
+		return "RSA"
+	case "EC PRIVATE KEY":
+		return "ECDSA"
+	case "DSA PRIVATE KEY":
+		return "DSA"
+	}
+	return "unknown"
+}
+
+// describePublicKey reports type, size and the SHA256 fingerprint of the
+// DER-encoded SubjectPublicKeyInfo.
+func describePublicKey(pub crypto.PublicKey) (keyInfo, error) {
+	info := keyInfo{}
+
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		info.keyType = "RSA"
+		info.bits = k.N.BitLen()
+	case *ecdsa.PublicKey:
+		info.keyType = "ECDSA"
+		info.bits = k.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		info.keyType = "Ed25519"
+		info.bits = 256
+	default:
+		info.keyType = fmt.Sprintf("%T", pub)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err == nil {
+		info.fingerprint = fingerprint(der)
+	}
+	return info, nil
+}
+
+func fingerprint(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+const openSSHMagic = "openssh-key-v1\x00"
+
+// parseOpenSSHKey reads the unencrypted header of an openssh-key-v1 blob.
+// The public key is stored in the clear even for encrypted keys, so type,
+// size and fingerprint are available either way.
+func parseOpenSSHKey(data []byte) (keyInfo, error) {
+	if !strings.HasPrefix(string(data), openSSHMagic) {
+		return keyInfo{}, errors.New("invalid OpenSSH key data")
+	}
+	r := sshReader{data: data[len(openSSHMagic):]}
+
+	cipher := r.readString()
+	r.readString() // kdfname
+	r.readString() // kdfoptions
+	if r.readUint32() < 1 {
+		return keyInfo{}, errors.New("OpenSSH key has no keys")
+	}
+	pubBlob := r.readString()
+	if r.err != nil {
+		return keyInfo{}, errors.New("truncated OpenSSH key data")
+	}
+
+	info := keyInfo{
+		encrypted:   string(cipher) != "none",
+		fingerprint: fingerprint(pubBlob),
+	}
+
+	pub := sshReader{data: pubBlob}
+	keyType := string(pub.readString())
+	switch {
+	case keyType == "ssh-rsa":
+		pub.readString() // e
+		n := pub.readString()
+		info.keyType = "RSA"
+		info.bits = new(big.Int).SetBytes(n).BitLen()
+	case keyType == "ssh-ed25519":
+		info.keyType = "Ed25519"
+		info.bits = 256
+	case strings.HasPrefix(keyType, "ecdsa-sha2-nistp"):
+		info.keyType = "ECDSA"
+		fmt.Sscanf(strings.TrimPrefix(keyType, "ecdsa-sha2-nistp"), "%d", &info.bits)
+	case keyType == "ssh-dss":
+		p := pub.readString()
+		info.keyType = "DSA"
+		info.bits = new(big.Int).SetBytes(p).BitLen()
+	default:
+		info.keyType = keyType
+	}
+	if pub.err != nil {
+		return keyInfo{}, errors.New("truncated OpenSSH public key")
+	}
+
+	return info, nil
+}
+
+// sshReader decodes the length-prefixed fields of the SSH wire format.
+type sshReader struct {
+	data []byte
+	err  error
+}
+
+func (r *sshReader) readUint32() uint32 {
+	if r.err != nil || len(r.data) < 4 {
+		r.err = errors.New("short read")
+		return 0
+	}
+	v := binary.BigEndian.Uint32(r.data)
+	r.data = r.data[4:]
+	return v
+}
+
+func (r *sshReader) readString() []byte {
+	n := r.readUint32()
+	if r.err != nil || uint32(len(r.data)) < n {
+		r.err = errors.New("short read")
+		return nil
+	}
+	s := r.data[:n]
+	r.data = r.data[n:]
+	return s
+}
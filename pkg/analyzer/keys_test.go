@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Private Key Parsing
+// Keys are generated at runtime so no key material is committed
+// =============================================================================
+
+func pemText(blockType string, der []byte, headers map[string]string) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: blockType, Headers: headers, Bytes: der}))
+}
+
+func sshString(b []byte) []byte {
+	out := make([]byte, 4+len(b))
+	binary.BigEndian.PutUint32(out, uint32(len(b)))
+	copy(out[4:], b)
+	return out
+}
+
+func makeOpenSSHKey(cipher string, pub ed25519.PublicKey) []byte {
+	pubBlob := append(sshString([]byte("ssh-ed25519")), sshString(pub)...)
+
+	data := []byte(openSSHMagic)
+	data = append(data, sshString([]byte(cipher))...)
+	data = append(data, sshString([]byte("none"))...)
+	data = append(data, sshString(nil)...)
+	data = append(data, 0, 0, 0, 1)
+	data = append(data, sshString(pubBlob)...)
+	data = append(data, sshString([]byte("private-section"))...)
+	return data
+}
+
+func TestParsePrivateKeyBlock(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	edPub, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edKey)
+
+	tests := []struct {
+		name          string
+		text          string
+		wantType      string
+		wantBits      int
+		wantEncrypted bool
+	}{
+		{"PKCS#1 RSA", pemText("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), nil), "RSA", 2048, false},
+		{"SEC1 EC", pemText("EC PRIVATE KEY", ecDER, nil), "ECDSA", 384, false},
+		{"PKCS#8 Ed25519", pemText("PRIVATE KEY", edDER, nil), "Ed25519", 256, false},
+		{"legacy encrypted RSA", pemText("RSA PRIVATE KEY", []byte("ciphertext"), map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00"}), "RSA", 0, true},
+		{"OpenSSH Ed25519", pemText("OPENSSH PRIVATE KEY", makeOpenSSHKey("none", edPub), nil), "Ed25519", 256, false},
+		{"encrypted OpenSSH", pemText("OPENSSH PRIVATE KEY", makeOpenSSHKey("aes256-ctr", edPub), nil), "Ed25519", 256, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			info, err := parsePrivateKeyBlock(tc.text)
+			if err != nil {
+				t.Fatalf("parsePrivateKeyBlock returned error: %v", err)
+			}
+			if info.keyType != tc.wantType || info.bits != tc.wantBits || info.encrypted != tc.wantEncrypted {
+				t.Errorf("Got type=%s bits=%d encrypted=%v, want %s/%d/%v",
+					info.keyType, info.bits, info.encrypted, tc.wantType, tc.wantBits, tc.wantEncrypted)
+			}
+			if !tc.wantEncrypted && !strings.HasPrefix(info.fingerprint, "SHA256:") {
+				t.Errorf("Expected a SHA256 fingerprint, got %q", info.fingerprint)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFileClassifiesPrivateKeys(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ecKey)
+	full := pemText("EC PRIVATE KEY", ecDER, nil)
+
+	t.Run("complete block", func(t *testing.T) {
+		findings := AnalyzeFile(writeTestFile(t, "server.key", full))
+		keys := findingsOfType(findings, "EC Private Key")
+		if len(keys) != 1 {
+			t.Fatalf("Expected 1 EC Private Key finding, got %+v", findings)
+		}
+		if keys[0].Severity != "HIGH" || keys[0].Details["bits"] != "256" {
+			t.Errorf("Unexpected classification: severity=%s details=%v", keys[0].Severity, keys[0].Details)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(full, "\n"), "\n")
+		if keys[0].LineNumber != 1 || keys[0].EndLine != len(lines) {
+			t.Errorf("Span = lines %d-%d, want 1-%d", keys[0].LineNumber, keys[0].EndLine, len(lines))
+		}
+		if keys[0].EndColumn != len(lines[len(lines)-1]) {
+			t.Errorf("EndColumn = %d, want end of END line (%d)", keys[0].EndColumn, len(lines[len(lines)-1]))
+		}
+	})
+
+	t.Run("truncated snippet", func(t *testing.T) {
+		lines := strings.Split(full, "\n")
+		snippet := strings.Join(lines[:2], "\n") + "\n"
+		keys := findingsOfType(AnalyzeFile(writeTestFile(t, "README.md", snippet)), "EC Private Key")
+		if len(keys) != 1 || keys[0].Severity != "LOW" {
+			t.Errorf("Expected truncated key to be LOW severity, got %+v", keys)
+		}
+	})
+
+	t.Run("escaped in JSON", func(t *testing.T) {
+		escaped := strings.ReplaceAll(full, "\n", `\n`)
+		keys := findingsOfType(AnalyzeFile(writeTestFile(t, "sa.json", `{"private_key": "`+escaped+`"}`)), "EC Private Key")
+		if len(keys) != 1 || keys[0].Details["key_type"] != "ECDSA" {
+			t.Fatalf("Expected escaped key to be parsed, got %+v", keys)
+		}
+		if keys[0].MultiLine() || keys[0].EndColumn != len(`{"private_key": "`+strings.TrimSuffix(escaped, `\n`)) {
+			t.Errorf("Expected single-line span ending at the END marker, got line %d-%d end column %d", keys[0].LineNumber, keys[0].EndLine, keys[0].EndColumn)
+		}
+	})
+}
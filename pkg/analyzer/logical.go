@@ -0,0 +1,267 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Kinds of logical strings, recorded in Details["joined_from"].
+const (
+	joinConcatenation = "concatenation"
+	joinContinuation  = "continuation"
+	joinYAMLBlock     = "yaml block"
+)
+
+const stringLiteral = `"(?:[^"\\\n]|\\.)*"|'(?:[^'\\\n]|\\.)*'|` + "`[^`\\n]*`"
+
+var (
+	stringLiteralPattern = regexp.MustCompile(stringLiteral)
+
+	// literalChainPattern matches two or more string literals joined by +,
+	// . (PHP/Perl), .. (Lua), & (VB) or plain adjacency, on one line or
+	// across a line break. concatenatedLiterals splits chains at bare line
+	// breaks outside brackets, where adjacent literals are separate values.
+	literalChainPattern = regexp.MustCompile(`(?:` + stringLiteral + `)(?:[ \t]*\n?[ \t]*(?:\+|\.\.?|&)?[ \t]*\n?[ \t]*(?:` + stringLiteral + `))+`)
+
+	yamlBlockPattern = regexp.MustCompile(`^(\s*(?:-\s+)?)(?:[\w.-]+|"[^"]+"|'[^']+')\s*:\s*[|>][-+0-9]*\s*$`)
+)
+
+// logicalString is text reassembled from pieces of one or more physical
+// lines. origins maps every byte of text back to where it came from.
+type logicalString struct {
+	kind    string
+	text    strings.Builder
+	origins []origin
+	pieces  int
+}
+
+// origin is the physical position of one byte of a logical string.
+type origin struct {
+	line   int // 1-based
+	column int // byte offset into the line
+	piece  int // contiguous source run the byte belongs to
+}
+
+// add appends s, which starts at the given physical position, as a new
+// contiguous piece.
+func (ls *logicalString) add(s string, line, column int) {
+	ls.text.WriteString(s)
+	for i := range []byte(s) {
+		ls.origins = append(ls.origins, origin{line: line, column: column + i, piece: ls.pieces})
+	}
+	ls.pieces++
+}
+
+// scanLogicalStrings reassembles string literal concatenations, backslash
+// continuation lines and YAML block scalars, runs the regex rules over the
+// result and maps matches back to their physical lines. Only matches that
+// cross a join are returned; the rest were already found line by line.
+func scanLogicalStrings(filePath string, lines []string, rules *ruleSet) []Finding {
+	findings := make([]Finding, 0)
+
+	logical := concatenatedLiterals(lines)
+	logical = append(logical, continuationLines(lines)...)
+	logical = append(logical, yamlBlocks(lines)...)
+
+	for _, ls := range logical {
+		if ls.pieces < 2 {
+			continue
+		}
+		for _, f := range matchPatterns(ls.text.String(), 0, filePath, rules) {
+			if f.Column < 0 || f.EndColumn > len(ls.origins) || f.Column >= f.EndColumn {
+				continue
+			}
+			start, end := ls.origins[f.Column], ls.origins[f.EndColumn-1]
+			if start.piece == end.piece {
+				continue
+			}
+
+			f.LineNumber, f.Column = start.line, start.column
+			f.EndLine, f.EndColumn = end.line, end.column+1
+
+			if f.Details == nil {
+				f.Details = make(map[string]string)
+			}
+			f.Details["joined_from"] = ls.kind
+			findings = append(findings, f)
+		}
+	}
+
+	return findings
+}
+
+// concatenatedLiterals joins chains such as "AKIA" + "IOSF..." into one
+// quoted string, keeping the text before the chain on its first line so
+// keyword rules still see the variable name.
+func concatenatedLiterals(lines []string) []*logicalString {
+	text := strings.Join(lines, "\n")
+	lineStarts := make([]int, len(lines))
+	offset := 0
+	for i, line := range lines {
+		lineStarts[i] = offset
+		offset += len(line) + 1
+	}
+	position := func(off int) (int, int) {
+		line := sort.Search(len(lineStarts), func(i int) bool { return lineStarts[i] > off }) - 1
+		return line + 1, off - lineStarts[line]
+	}
+
+	brackets := &bracketTracker{text: text}
+
+	logical := make([]*logicalString, 0)
+	for _, chain := range literalChainPattern.FindAllStringIndex(text, -1) {
+		literals := stringLiteralPattern.FindAllStringIndex(text[chain[0]:chain[1]], -1)
+		for i := range literals {
+			literals[i][0] += chain[0]
+			literals[i][1] += chain[0]
+		}
+
+		// Only an operator or an open bracket carries a chain to the next
+		// line; "a"\n"b" are two statements.
+		start := 0
+		for i := 1; i <= len(literals); i++ {
+			if i < len(literals) {
+				gap := text[literals[i-1][1]:literals[i][0]]
+				if !strings.Contains(gap, "\n") || strings.TrimSpace(gap) != "" || brackets.open(literals[i-1][1]) {
+					continue
+				}
+			}
+			if i-start >= 2 {
+				logical = append(logical, joinLiterals(text, literals[start:i], lineStarts, position))
+			}
+			start = i
+		}
+	}
+
+	return logical
+}
+
+// joinLiterals builds the logical string for one chain of literals.
+func joinLiterals(text string, literals [][]int, lineStarts []int, position func(int) (int, int)) *logicalString {
+	ls := &logicalString{kind: joinConcatenation}
+	for i, lit := range literals {
+		from, to := lit[0]+1, lit[1]-1
+		if i == 0 {
+			line, _ := position(from)
+			from = lineStarts[line-1]
+		}
+		if i == len(literals)-1 {
+			to++
+		}
+		line, column := position(from)
+		ls.add(text[from:to], line, column)
+	}
+	return ls
+}
+
+// bracketTracker reports whether a bracket is open at increasing offsets
+// of text, ignoring brackets inside string literals. It only scans the
+// text once it is first asked.
+type bracketTracker struct {
+	text     string
+	literals [][]int // string literals in text, found on first use
+	offset   int     // text before offset has been scanned
+	next     int     // first literal that may still lie ahead
+	depth    int
+}
+
+// open reports whether an opening (, [ or { before off is still unclosed.
+// Calls must use non-decreasing offsets.
+func (b *bracketTracker) open(off int) bool {
+	if b.literals == nil {
+		b.literals = stringLiteralPattern.FindAllStringIndex(b.text, -1)
+	}
+	for b.offset < off {
+		for b.next < len(b.literals) && b.literals[b.next][1] <= b.offset {
+			b.next++
+		}
+		if b.next < len(b.literals) && b.literals[b.next][0] <= b.offset {
+			b.offset = b.literals[b.next][1]
+			continue
+		}
+		switch b.text[b.offset] {
+		case '(', '[', '{':
+			b.depth++
+		case ')', ']', '}':
+			if b.depth > 0 {
+				b.depth--
+			}
+		}
+		b.offset++
+	}
+	return b.depth > 0
+}
+
+// continuationLines joins lines ending in a backslash with the lines that
+// follow, dropping the backslash and the next line's indentation.
+func continuationLines(lines []string) []*logicalString {
+	logical := make([]*logicalString, 0)
+
+	for i := 0; i < len(lines); i++ {
+		if !endsWithContinuation(lines[i]) {
+			continue
+		}
+
+		ls := &logicalString{kind: joinContinuation}
+		for ; i < len(lines); i++ {
+			line := lines[i]
+			trimmed := strings.TrimLeft(line, " \t")
+			column := len(line) - len(trimmed)
+			if ls.pieces == 0 {
+				trimmed, column = line, 0
+			}
+
+			if !endsWithContinuation(line) {
+				ls.add(trimmed, i+1, column)
+				break
+			}
+			ls.add(strings.TrimSuffix(strings.TrimRight(trimmed, " \t"), `\`), i+1, column)
+		}
+		logical = append(logical, ls)
+	}
+
+	return logical
+}
+
+func endsWithContinuation(line string) bool {
+	line = strings.TrimRight(line, " \t")
+	return strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`)
+}
+
+// yamlBlocks joins the content lines of YAML literal (|) and folded (>)
+// block scalars onto their key, without separators, so values wrapped
+// across lines are seen whole.
+func yamlBlocks(lines []string) []*logicalString {
+	logical := make([]*logicalString, 0)
+
+	for i := 0; i < len(lines); i++ {
+		m := yamlBlockPattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		keyIndent := len(m[1])
+		indicator := strings.LastIndexAny(lines[i], "|>")
+
+		ls := &logicalString{kind: joinYAMLBlock}
+		ls.add(lines[i][:indicator], i+1, 0)
+
+		for i+1 < len(lines) {
+			next := lines[i+1]
+			trimmed := strings.TrimLeft(next, " \t")
+			if trimmed == "" {
+				i++
+				continue
+			}
+			indent := len(next) - len(trimmed)
+			if indent <= keyIndent {
+				break
+			}
+			ls.add(strings.TrimRight(trimmed, " \t"), i+2, indent)
+			i++
+		}
+		logical = append(logical, ls)
+	}
+
+	return logical
+}
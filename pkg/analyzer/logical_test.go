@@ -0,0 +1,135 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Logical String Reassembly
+// Tests that secrets split across literals and lines are found and mapped back
+// =============================================================================
+
+func TestScanLogicalStrings(t *testing.T) {
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+	gitlab := fakeToken("glpat-", charsAlnum, 20)
+
+	tests := []struct {
+		name        string
+		content     string
+		wantType    string
+		wantLine    int
+		wantColumn  int
+		wantEndLine int
+		wantKind    string
+	}{
+		{
+			name:        "plus concatenation on one line",
+			content:     `key = "` + awsKey[:4] + `" + "` + awsKey[4:] + `"` + "\n",
+			wantType:    "AWS Access Key ID",
+			wantLine:    1,
+			wantColumn:  7,
+			wantEndLine: 1,
+			wantKind:    joinConcatenation,
+		},
+		{
+			name:        "concatenation across lines",
+			content:     "x = 1\ntoken = '" + gitlab[:10] + "' .\n    '" + gitlab[10:] + "';\n",
+			wantType:    "GitLab Personal Access Token",
+			wantLine:    2,
+			wantColumn:  9,
+			wantEndLine: 3,
+			wantKind:    joinConcatenation,
+		},
+		{
+			name:        "adjacent Python literals",
+			content:     "KEY = (\"" + awsKey[:8] + "\"\n       \"" + awsKey[8:] + "\")\n",
+			wantType:    "AWS Access Key ID",
+			wantLine:    1,
+			wantColumn:  8,
+			wantEndLine: 2,
+			wantKind:    joinConcatenation,
+		},
+		{
+			name:        "backslash continuation",
+			content:     "export TOKEN=" + gitlab[:12] + "\\\n  " + gitlab[12:] + "\n",
+			wantType:    "GitLab Personal Access Token",
+			wantLine:    1,
+			wantColumn:  13,
+			wantEndLine: 2,
+			wantKind:    joinContinuation,
+		},
+		{
+			name:        "YAML folded block",
+			content:     "auth:\n  token: >\n    " + gitlab[:8] + "\n    " + gitlab[8:] + "\nnext: 1\n",
+			wantType:    "GitLab Personal Access Token",
+			wantLine:    3,
+			wantColumn:  4,
+			wantEndLine: 4,
+			wantKind:    joinYAMLBlock,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := strings.Split(strings.TrimSuffix(tc.content, "\n"), "\n")
+			findings := findingsOfType(scanLogicalStrings("test.txt", lines, DefaultOptions().rules()), tc.wantType)
+			if len(findings) != 1 {
+				t.Fatalf("Expected one %q finding, got %+v", tc.wantType, findings)
+			}
+
+			f := findings[0]
+			if f.LineNumber != tc.wantLine || f.Column != tc.wantColumn {
+				t.Errorf("Position = %d:%d, want %d:%d", f.LineNumber, f.Column, tc.wantLine, tc.wantColumn)
+			}
+			if f.EndLine != tc.wantEndLine {
+				t.Errorf("EndLine = %d, want %d", f.EndLine, tc.wantEndLine)
+			}
+			if f.Details["joined_from"] != tc.wantKind {
+				t.Errorf("joined_from = %q, want %q", f.Details["joined_from"], tc.wantKind)
+			}
+		})
+	}
+}
+
+func TestScanLogicalStringsSkipsSinglePieceMatches(t *testing.T) {
+	// The key is whole inside one literal, so the line pass already has it.
+	lines := []string{`key = "AKIA` + `IOSFODNN7EXAMPLE" + "suffix"`}
+	if findings := scanLogicalStrings("test.txt", lines, DefaultOptions().rules()); len(findings) != 0 {
+		t.Errorf("Expected no joined findings, got %+v", findings)
+	}
+}
+
+func TestScanLogicalStringsNeedsAJoinAcrossLines(t *testing.T) {
+	awsKey := "AKIA" + "IOSFODNN7EXAMPLE"
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"separate statements", "a = \"" + awsKey[:8] + "\"\n\"" + awsKey[8:] + "\"\n"},
+		{"bracket closed earlier", "f(\"x\")\n\"" + awsKey[:8] + "\"\n\"" + awsKey[8:] + "\"\n"},
+		{"bracket inside a literal", "open = \"(\"\n\"" + awsKey[:8] + "\"\n\"" + awsKey[8:] + "\"\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lines := strings.Split(strings.TrimSuffix(tc.content, "\n"), "\n")
+			if findings := scanLogicalStrings("test.txt", lines, DefaultOptions().rules()); len(findings) != 0 {
+				t.Errorf("Expected no joined findings, got %+v", findings)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFileFindsSplitSecrets(t *testing.T) {
+	path := writeTestFile(t, "config.py", "AWS_KEY = \"AKIA\" + \"IOSFODNN7EXAMPLE\"\n")
+
+	findings := findingsOfType(AnalyzeFile(path), "AWS Access Key ID")
+	if len(findings) != 1 {
+		t.Fatalf("Expected the concatenated AWS key to be found, got %+v", findings)
+	}
+	if findings[0].LineNumber != 1 {
+		t.Errorf("LineNumber = %d, want 1", findings[0].LineNumber)
+	}
+}
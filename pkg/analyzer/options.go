@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// engineVersion is bumped whenever detection logic outside the regex rules
+// changes (validators, correlation, span handling), so that results cached
+// by an older build are discarded.
+const engineVersion = 2
+
+// Provider IDs for the detectors that are not regex rules.
+const (
+	ProviderEntropy     = "entropy"
+	ProviderDatabase    = "database"
+	ProviderCertificate = "certificate"
+	ProviderGo          = "go"
+)
+
+// Options controls which detectors AnalyzeFileWithOptions applies.
+type Options struct {
+	// EnabledProviders restricts scanning to these provider IDs.
+	// Empty means every provider is enabled.
+	EnabledProviders []string
+
+	// DisabledProviders lists provider IDs whose detectors are skipped.
+	// It takes precedence over EnabledProviders.
+	DisabledProviders []string
+
+	// EnablePII turns on the personal data detectors (card numbers, IBANs,
+	// SSNs, email addresses). Their findings use CategoryPII.
+	EnablePII bool
+
+	// ContextLines is the number of lines before and after each finding
+	// captured in Finding.Context. Zero captures none.
+	ContextLines int
+}
+
+// DefaultOptions returns Options with every provider enabled.
+func DefaultOptions() Options {
+	return Options{}
+}
+
+// ProviderEnabled reports whether detectors for provider should run.
+func (o Options) ProviderEnabled(provider string) bool {
+	for _, p := range o.DisabledProviders {
+		if p == provider {
+			return false
+		}
+	}
+	if len(o.EnabledProviders) == 0 {
+		return true
+	}
+	for _, p := range o.EnabledProviders {
+		if p == provider {
+			return true
+		}
+	}
+	return false
+}
+
+// patterns returns the regex rules enabled by o, built-in rules first.
+func (o Options) patterns() []SecretPattern {
+	lists := [][]SecretPattern{secretPatterns, detectorPack, walletPatterns}
+	if o.EnablePII {
+		lists = append(lists, piiPatterns)
+	}
+
+	enabled := make([]SecretPattern, 0, len(secretPatterns)+len(detectorPack)+len(walletPatterns)+len(piiPatterns))
+	for _, list := range lists {
+		for _, p := range list {
+			if o.ProviderEnabled(p.Provider) {
+				enabled = append(enabled, p)
+			}
+		}
+	}
+	return enabled
+}
+
+// Rules returns every regex rule known to the analyzer, including the
+// opt-in PII rules.
+func Rules() []SecretPattern {
+	return Options{EnablePII: true}.patterns()
+}
+
+// Providers returns the sorted provider IDs that can be enabled or disabled.
+func Providers() []string {
+	seen := map[string]bool{
+		ProviderEntropy:     true,
+		ProviderDatabase:    true,
+		ProviderCertificate: true,
+		ProviderGo:          true,
+	}
+	for _, p := range Rules() {
+		seen[p.Provider] = true
+	}
+
+	providers := make([]string, 0, len(seen))
+	for p := range seen {
+		providers = append(providers, p)
+	}
+	sort.Strings(providers)
+	return providers
+}
+
+// Validate reports provider IDs in EnabledProviders or DisabledProviders
+// that do not exist. A misspelled ID would otherwise silently disable every
+// rule (when enabling) or none (when disabling).
+func (o Options) Validate() error {
+	known := make(map[string]bool)
+	for _, p := range Providers() {
+		known[p] = true
+	}
+
+	var unknown []string
+	for _, p := range append(append([]string(nil), o.EnabledProviders...), o.DisabledProviders...) {
+		if !known[p] {
+			unknown = append(unknown, p)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown provider %s; valid providers are: %s",
+			strings.Join(unknown, ", "), strings.Join(Providers(), ", "))
+	}
+	return nil
+}
+
+// RulesVersion identifies the findings o produces: the enabled rules and
+// detectors, the analyzer build and the options that shape each finding.
+// Results cached under one version are not valid under another.
+func (o Options) RulesVersion() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "engine %d\ncontext %d\n", engineVersion, o.ContextLines)
+	for _, p := range o.patterns() {
+		fmt.Fprintf(h, "rule %q %q %q %q %v %v %v\n", p.Name, p.Pattern, p.Severity, p.Category, p.Generic, p.Keywords, p.CaseSensitive)
+	}
+	for _, provider := range []string{ProviderEntropy, ProviderDatabase, ProviderCertificate, ProviderGo} {
+		fmt.Fprintf(h, "detector %s %v\n", provider, o.ProviderEnabled(provider))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
@@ -0,0 +1,136 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Character sets used to build the synthetic test vectors below. Vectors are
+// assembled at init time so no token-shaped literals live in the source.
+const (
+	charsAlnum     = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	charsHex       = "0123456789abcdef"
+	charsUpperNum  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	charsBase64URL = charsAlnum + "-_"
+	charsBase64    = charsAlnum + "+/"
+)
+
+// fakeToken builds a deterministic string of n characters from charset.
+func fakeToken(prefix, charset string, n int) string {
+	var sb strings.Builder
+	sb.WriteString(prefix)
+	for i := 0; i < n; i++ {
+		sb.WriteByte(charset[(i*7+3)%len(charset)])
+	}
+	return sb.String()
+}
+
+// detectorPack holds provider rules maintained alongside secretPatterns.
+// Each rule carries a provider ID, keyword hints and test vectors; the
+// vectors are checked by the test suite.
+var detectorPack = []SecretPattern{
+	// Azure
+	{Name: "Azure Storage Account Key", Pattern: regexp.MustCompile(`(?i)AccountKey=[A-Za-z0-9+/]{86}==`), Severity: "HIGH", Provider: "azure",
+		Keywords: []string{"accountkey="},
+		Examples: []string{"DefaultEndpointsProtocol=https;AccountName=prod;AccountKey=" + fakeToken("", charsBase64, 86) + "==;"}},
+	{Name: "Azure SAS Token", Pattern: regexp.MustCompile(`sv=\d{4}-\d{2}-\d{2}&[^\s"'<>]*sig=[A-Za-z0-9%+/]{40,}`), Severity: "HIGH", Provider: "azure",
+		Keywords: []string{"sig="},
+		Examples: []string{"https://acct.blob.core.windows.net/c?sv=2022-11-02&ss=b&srt=co&sp=rl&sig=" + fakeToken("", charsAlnum, 43) + "%3D"}},
+
+	// Google Cloud
+	{Name: "GCP Service Account Key", Pattern: regexp.MustCompile(`"type"\s*:\s*"service_account"`), Severity: "HIGH", Provider: "gcp",
+		Keywords: []string{"service_account"},
+		Examples: []string{`{"type": "service_account", "project_id": "prod"}`}},
+
+	// GitLab
+	{Name: "GitLab Personal Access Token", Pattern: regexp.MustCompile(`glpat-[0-9A-Za-z_-]{20}`), Severity: "HIGH", Provider: "gitlab",
+		Keywords: []string{"glpat-"},
+		Examples: []string{fakeToken("glpat-", charsAlnum, 20)}},
+	{Name: "GitLab Pipeline Trigger Token", Pattern: regexp.MustCompile(`glptt-[0-9a-f]{40}`), Severity: "HIGH", Provider: "gitlab",
+		Keywords: []string{"glptt-"},
+		Examples: []string{fakeToken("glptt-", charsHex, 40)}},
+	{Name: "GitLab Runner Token", Pattern: regexp.MustCompile(`glrt-[0-9A-Za-z_-]{20}`), Severity: "HIGH", Provider: "gitlab",
+		Keywords: []string{"glrt-"},
+		Examples: []string{fakeToken("glrt-", charsAlnum, 20)}},
+
+	// AI providers
+	{Name: "OpenAI API Key", Pattern: regexp.MustCompile(`sk-(?:proj-|svcacct-|admin-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,}`), Severity: "HIGH", Provider: "openai",
+		Keywords: []string{"t3blbkfj"},
+		Examples: []string{fakeToken("sk-", charsAlnum, 20) + "T3BlbkFJ" + fakeToken("", charsAlnum, 20),
+			fakeToken("sk-proj-", charsBase64URL, 40) + "T3BlbkFJ" + fakeToken("", charsBase64URL, 40)}},
+	{Name: "Anthropic API Key", Pattern: regexp.MustCompile(`sk-ant-(?:api03|admin01)-[A-Za-z0-9_-]{93}AA`), Severity: "HIGH", Provider: "anthropic",
+		Keywords: []string{"sk-ant-"},
+		Examples: []string{fakeToken("sk-ant-api03-", charsBase64URL, 93) + "AA"}},
+	{Name: "Hugging Face Access Token", Pattern: regexp.MustCompile(`hf_[A-Za-z]{34}`), Severity: "HIGH", Provider: "huggingface",
+		Keywords: []string{"hf_"},
+		Examples: []string{fakeToken("hf_", "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ", 34)}},
+
+	// Cloud platforms
+	{Name: "DigitalOcean Token", Pattern: regexp.MustCompile(`do[por]_v1_[a-f0-9]{64}`), Severity: "HIGH", Provider: "digitalocean",
+		Keywords: []string{"dop_v1_", "doo_v1_", "dor_v1_"},
+		Examples: []string{fakeToken("dop_v1_", charsHex, 64)}},
+
+	// Commerce and payments
+	{Name: "Shopify Access Token", Pattern: regexp.MustCompile(`shp(?:at|ca|pa|ss)_[a-fA-F0-9]{32}`), Severity: "HIGH", Provider: "shopify",
+		Keywords: []string{"shpat_", "shpca_", "shppa_", "shpss_"},
+		Examples: []string{fakeToken("shpat_", charsHex, 32)}},
+	{Name: "Square Access Token", Pattern: regexp.MustCompile(`sq0atp-[0-9A-Za-z_-]{22}`), Severity: "HIGH", Provider: "square",
+		Keywords: []string{"sq0atp-"},
+		Examples: []string{fakeToken("sq0atp-", charsBase64URL, 22)}},
+	{Name: "Square OAuth Secret", Pattern: regexp.MustCompile(`sq0csp-[0-9A-Za-z_-]{43}`), Severity: "HIGH", Provider: "square",
+		Keywords: []string{"sq0csp-"},
+		Examples: []string{fakeToken("sq0csp-", charsBase64URL, 43)}},
+	{Name: "Braintree Access Token", Pattern: regexp.MustCompile(`access_token\$production\$[0-9a-z]{16}\$[0-9a-f]{32}`), Severity: "HIGH", Provider: "braintree",
+		Keywords: []string{"access_token$production$"},
+		Examples: []string{"access_token$production$" + fakeToken("", "0123456789abcdefghijklmnopqrstuvwxyz", 16) + "$" + fakeToken("", charsHex, 32)}},
+
+	// Developer tooling
+	{Name: "Atlassian API Token", Pattern: regexp.MustCompile(`ATATT3[A-Za-z0-9_\-=]{186}`), Severity: "HIGH", Provider: "atlassian",
+		Keywords: []string{"atatt3"},
+		Examples: []string{fakeToken("ATATT3", charsBase64URL, 186)}},
+	{Name: "Datadog API Key", Pattern: regexp.MustCompile(`(?i)(?:datadog|dd)[\w.-]{0,20}(?:api|app)[_-]?key['"]?\s*[:=]\s*['"]?(?:[a-f0-9]{40}|[a-f0-9]{32})\b`), Severity: "HIGH", Provider: "datadog",
+		Keywords: []string{"datadog", "dd"},
+		Examples: []string{"DD_API_KEY=" + fakeToken("", charsHex, 32), `datadog_app_key: "` + fakeToken("", charsHex, 40) + `"`}},
+	{Name: "Postman API Key", Pattern: regexp.MustCompile(`PMAK-[a-f0-9]{24}-[a-f0-9]{34}`), Severity: "HIGH", Provider: "postman",
+		Keywords: []string{"pmak-"},
+		Examples: []string{fakeToken("PMAK-", charsHex, 24) + fakeToken("-", charsHex, 34)}},
+	{Name: "Linear API Key", Pattern: regexp.MustCompile(`lin_api_[A-Za-z0-9]{40}`), Severity: "HIGH", Provider: "linear",
+		Keywords: []string{"lin_api_"},
+		Examples: []string{fakeToken("lin_api_", charsAlnum, 40)}},
+	{Name: "PyPI Upload Token", Pattern: regexp.MustCompile(`pypi-AgEIcHlwaS5vcmc[A-Za-z0-9_-]{50,}`), Severity: "HIGH", Provider: "pypi",
+		Keywords: []string{"pypi-ageichlwas5vcmc"},
+		Examples: []string{fakeToken("pypi-AgEIcHlwaS5vcmc", charsBase64URL, 60)}},
+	{Name: "Docker Hub Access Token", Pattern: regexp.MustCompile(`dckr_pat_[A-Za-z0-9_-]{27}`), Severity: "HIGH", Provider: "docker",
+		Keywords: []string{"dckr_pat_"},
+		Examples: []string{fakeToken("dckr_pat_", charsBase64URL, 27)}},
+
+	// Observability
+	{Name: "New Relic User API Key", Pattern: regexp.MustCompile(`NRAK-[A-Z0-9]{27}`), Severity: "HIGH", Provider: "newrelic",
+		Keywords: []string{"nrak-"},
+		Examples: []string{fakeToken("NRAK-", charsUpperNum, 27)}},
+	{Name: "Grafana Service Account Token", Pattern: regexp.MustCompile(`glsa_[A-Za-z0-9]{32}_[A-Fa-f0-9]{8}`), Severity: "HIGH", Provider: "grafana",
+		Keywords: []string{"glsa_"},
+		Examples: []string{fakeToken("glsa_", charsAlnum, 32) + fakeToken("_", charsHex, 8)}},
+
+	// Secrets management and infrastructure
+	{Name: "HashiCorp Vault Token", Pattern: regexp.MustCompile(`hv[sb]\.[A-Za-z0-9_-]{24,}`), Severity: "HIGH", Provider: "hashicorp",
+		Keywords: []string{"hvs.", "hvb."},
+		Examples: []string{fakeToken("hvs.", charsBase64URL, 90)}},
+	{Name: "Terraform Cloud Token", Pattern: regexp.MustCompile(`[A-Za-z0-9]{14}\.atlasv1\.[A-Za-z0-9_-]{60,}`), Severity: "HIGH", Provider: "hashicorp",
+		Keywords: []string{".atlasv1."},
+		Examples: []string{fakeToken("", charsAlnum, 14) + ".atlasv1." + fakeToken("", charsBase64URL, 67)}},
+	{Name: "Doppler Token", Pattern: regexp.MustCompile(`dp\.(?:pt|st|sa|ct)\.[A-Za-z0-9]{40,44}`), Severity: "HIGH", Provider: "doppler",
+		Keywords: []string{"dp.pt.", "dp.st.", "dp.sa.", "dp.ct."},
+		Examples: []string{fakeToken("dp.pt.", charsAlnum, 43)}},
+	{Name: "Pulumi Access Token", Pattern: regexp.MustCompile(`pul-[a-f0-9]{40}`), Severity: "HIGH", Provider: "pulumi",
+		Keywords: []string{"pul-"},
+		Examples: []string{fakeToken("pul-", charsHex, 40)}},
+
+	// Messaging
+	{Name: "Telegram Bot Token", Pattern: regexp.MustCompile(`\b\d{8,10}:AA[0-9A-Za-z_-]{33}\b`), Severity: "HIGH", Provider: "telegram",
+		Keywords: []string{":aa"},
+		Examples: []string{"bot_token = 1234567890:AA" + fakeToken("", charsBase64URL, 33)}},
+	{Name: "Slack App-Level Token", Pattern: regexp.MustCompile(`xapp-\d-[A-Z0-9]+-\d+-[a-f0-9]{64}`), Severity: "HIGH", Provider: "slack",
+		Keywords: []string{"xapp-"},
+		Examples: []string{"xapp-1-A0123ABCDEF-1234567890123-" + fakeToken("", charsHex, 64)}},
+}
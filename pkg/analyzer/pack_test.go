@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Detector Pack
+// Every rule's test vectors must match and contain one of its keywords
+// =============================================================================
+
+func TestDetectorPackVectors(t *testing.T) {
+	for _, rule := range Rules() {
+		rule := rule
+		t.Run(rule.Name, func(t *testing.T) {
+			if rule.Provider == "" {
+				t.Errorf("Rule %q has no provider", rule.Name)
+			}
+
+			for _, example := range rule.Examples {
+				match := rule.Pattern.FindString(example)
+				if match == "" {
+					t.Errorf("Pattern does not match its example %q", example)
+					continue
+				}
+				if len(rule.Keywords) > 0 && !containsAnyKeyword(strings.ToLower(match), rule.Keywords) {
+					t.Errorf("Match %q contains none of the keywords %v", match, rule.Keywords)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectorPackRulesAreComplete(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, rule := range detectorPack {
+		if seen[rule.Name] {
+			t.Errorf("Duplicate rule name %q", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if len(rule.Examples) == 0 || len(rule.Keywords) == 0 || rule.Severity == "" {
+			t.Errorf("Rule %q needs examples, keywords and a severity", rule.Name)
+		}
+		for _, kw := range rule.Keywords {
+			if kw != strings.ToLower(kw) {
+				t.Errorf("Rule %q keyword %q must be lowercase", rule.Name, kw)
+			}
+		}
+	}
+}
+
+func TestAnalyzeFileReportsPackRules(t *testing.T) {
+	for _, rule := range detectorPack {
+		path := writeTestFile(t, "config.env", rule.Examples[0]+"\n")
+		opts := Options{EnabledProviders: []string{rule.Provider}}
+
+		found := false
+		for _, f := range AnalyzeFileWithOptions(path, opts) {
+			if f.Type == rule.Name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Expected %q to be reported for its example", rule.Name)
+		}
+	}
+}
+
+func TestOptionsProviderFiltering(t *testing.T) {
+	path := writeTestFile(t, "config.env", "GITLAB="+fakeToken("glpat-", charsAlnum, 20)+"\n")
+
+	if len(findingsOfType(AnalyzeFile(path), "GitLab Personal Access Token")) != 1 {
+		t.Error("Expected GitLab token with default options")
+	}
+
+	disabled := Options{DisabledProviders: []string{"gitlab"}}
+	if len(findingsOfType(AnalyzeFileWithOptions(path, disabled), "GitLab Personal Access Token")) != 0 {
+		t.Error("Expected no GitLab finding when the provider is disabled")
+	}
+
+	only := Options{EnabledProviders: []string{"aws"}}
+	if !only.ProviderEnabled("aws") || only.ProviderEnabled("gitlab") {
+		t.Error("EnabledProviders should restrict scanning to the listed providers")
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{"defaults", DefaultOptions(), false},
+		{"rule providers", Options{EnabledProviders: []string{"aws", "gitlab"}}, false},
+		{"detector providers", Options{DisabledProviders: []string{ProviderEntropy, ProviderDatabase, ProviderCertificate, ProviderGo}}, false},
+		{"typo in enabled", Options{EnabledProviders: []string{"awz"}}, true},
+		{"typo in disabled", Options{DisabledProviders: []string{"aws", "genric"}}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.opts.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Validate() = %v, wantErr %v", err, tc.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "aws, azure") {
+				t.Errorf("Expected the error to list the valid providers, got %v", err)
+			}
+		})
+	}
+}
+
+func containsAnyKeyword(s string, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(s, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRulesVersion(t *testing.T) {
+	base := DefaultOptions().RulesVersion()
+	if base != DefaultOptions().RulesVersion() {
+		t.Error("RulesVersion should be stable for equal options")
+	}
+
+	for name, opts := range map[string]Options{
+		"pii":      {EnablePII: true},
+		"disabled": {DisabledProviders: []string{"aws"}},
+		"detector": {DisabledProviders: []string{ProviderEntropy}},
+		"context":  {ContextLines: 2},
+	} {
+		if opts.RulesVersion() == base {
+			t.Errorf("%s: expected RulesVersion to change", name)
+		}
+	}
+}
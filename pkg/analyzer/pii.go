@@ -0,0 +1,166 @@
+package analyzer
+
+import (
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Finding categories. Secrets and personal data are counted separately.
+const (
+	CategorySecret = "secret"
+	CategoryPII    = "pii"
+)
+
+// ibanLengths are the IBAN lengths of common countries. Countries not
+// listed are accepted if the checksum holds and the length is plausible.
+var ibanLengths = map[string]int{
+	"AT": 20, "BE": 16, "CH": 21, "CZ": 24, "DE": 22, "DK": 18, "ES": 24,
+	"FI": 18, "FR": 27, "GB": 22, "GR": 27, "IE": 22, "IT": 27, "LU": 20,
+	"NL": 18, "NO": 15, "PL": 28, "PT": 25, "SE": 24,
+}
+
+// piiPatterns are only applied when Options.EnablePII is set.
+var piiPatterns = []SecretPattern{
+	{Name: "Credit Card Number", Pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), Severity: "HIGH", Provider: "pii", Category: CategoryPII,
+		Inspect:  inspectCardNumber,
+		Examples: []string{"card: 4111 1111 1111 1111", "5500-0000-0000-0004"}},
+	{Name: "IBAN", Pattern: regexp.MustCompile(`\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`), Severity: "HIGH", Provider: "pii", Category: CategoryPII,
+		Inspect:  inspectIBAN,
+		Examples: []string{"GB82 WEST 1234 5698 7654 32", "DE89370400440532013000"}},
+	{Name: "US Social Security Number", Pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`), Severity: "HIGH", Provider: "pii", Category: CategoryPII,
+		Inspect:  inspectSSN,
+		Examples: []string{"ssn=123-45-6789"}},
+	{Name: "Email Address", Pattern: regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), Severity: "MEDIUM", Provider: "pii", Category: CategoryPII, Generic: true,
+		Keywords: []string{"@"},
+		Inspect:  inspectEmail,
+		Examples: []string{"contact: jane.doe@corp-mail.net"}},
+}
+
+// inspectCardNumber keeps digit runs that pass the Luhn check and start with
+// a known card network prefix.
+func inspectCardNumber(f *Finding) bool {
+	digits := stripSeparators(f.Match)
+	if len(digits) < 13 || len(digits) > 19 || !knownCardPrefix(digits) || !luhnValid(digits) {
+		return false
+	}
+	f.Confidence = "HIGH"
+	f.Details = map[string]string{"last4": digits[len(digits)-4:]}
+	return true
+}
+
+func stripSeparators(s string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(s)
+}
+
+// knownCardPrefix checks the issuer identification ranges of the major
+// networks: Visa, Mastercard, Amex, Discover, JCB and Diners Club.
+func knownCardPrefix(digits string) bool {
+	prefix := func(n int) int {
+		v := 0
+		for _, c := range digits[:n] {
+			v = v*10 + int(c-'0')
+		}
+		return v
+	}
+
+	switch {
+	case digits[0] == '4':
+		return true
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return true
+	case prefix(2) == 34 || prefix(2) == 37:
+		return true
+	case prefix(4) == 6011 || prefix(2) == 65 || (prefix(3) >= 644 && prefix(3) <= 649):
+		return true
+	case prefix(2) == 35:
+		return true
+	case prefix(2) == 36 || prefix(2) == 38 || (prefix(3) >= 300 && prefix(3) <= 305):
+		return true
+	}
+	return false
+}
+
+// luhnValid implements the Luhn mod-10 checksum.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// inspectIBAN keeps candidates with a valid ISO 13616 mod-97 checksum.
+func inspectIBAN(f *Finding) bool {
+	iban := strings.ReplaceAll(f.Match, " ", "")
+	if want, ok := ibanLengths[iban[:2]]; ok && len(iban) != want {
+		return false
+	}
+	if len(iban) < 15 || len(iban) > 34 || !ibanChecksumValid(iban) {
+		return false
+	}
+	f.Confidence = "HIGH"
+	f.Details = map[string]string{"country": iban[:2]}
+	return true
+}
+
+func ibanChecksumValid(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+
+	var numeric strings.Builder
+	for _, c := range rearranged {
+		switch {
+		case c >= '0' && c <= '9':
+			numeric.WriteRune(c)
+		case c >= 'A' && c <= 'Z':
+			numeric.WriteString(strconv.Itoa(int(c-'A') + 10))
+		default:
+			return false
+		}
+	}
+
+	n, ok := new(big.Int).SetString(numeric.String(), 10)
+	if !ok {
+		return false
+	}
+	return new(big.Int).Mod(n, big.NewInt(97)).Int64() == 1
+}
+
+// inspectSSN applies the SSA's structural rules: area 000, 666 and 900-999,
+// group 00 and serial 0000 are never issued. Well-known advertising numbers
+// are dropped too.
+func inspectSSN(f *Finding) bool {
+	parts := strings.Split(f.Match, "-")
+	area, group, serial := parts[0], parts[1], parts[2]
+
+	if area == "000" || area == "666" || area[0] == '9' || group == "00" || serial == "0000" {
+		return false
+	}
+	if f.Match == "078-05-1120" || f.Match == "219-09-9999" {
+		return false
+	}
+	f.Confidence = "MEDIUM"
+	return true
+}
+
+// inspectEmail drops documentation and placeholder domains.
+func inspectEmail(f *Finding) bool {
+	domain := strings.ToLower(f.Match[strings.LastIndex(f.Match, "@")+1:])
+	for _, reserved := range []string{"example.com", "example.org", "example.net", "test.com", "localhost", "domain.com", "email.com"} {
+		if domain == reserved || strings.HasSuffix(domain, "."+reserved) {
+			return false
+		}
+	}
+	f.Confidence = "HIGH"
+	return true
+}
@@ -0,0 +1,129 @@
+package analyzer
+
+import "testing"
+
+// =============================================================================
+// TEST: PII Validators
+// Tests the checksum and structural checks behind the PII rules
+// =============================================================================
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		digits string
+		want   bool
+	}{
+		{"4111111111111111", true},
+		{"5500000000000004", true},
+		{"378282246310005", true},
+		{"4111111111111112", false},
+		{"1234567812345678", false},
+	}
+
+	for _, tc := range tests {
+		if got := luhnValid(tc.digits); got != tc.want {
+			t.Errorf("luhnValid(%q) = %v, want %v", tc.digits, got, tc.want)
+		}
+	}
+}
+
+func TestPIIInspectors(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		input    string
+		wantKeep bool
+	}{
+		// Card numbers
+		{"Visa spaced", "Credit Card Number", "4111 1111 1111 1111", true},
+		{"Mastercard dashed", "Credit Card Number", "5500-0000-0000-0004", true},
+		{"bad Luhn", "Credit Card Number", "4111 1111 1111 1112", false},
+		{"unknown issuer", "Credit Card Number", "9999 9999 9999 9995", false},
+		{"order number", "Credit Card Number", "1000000000000008", false},
+
+		// IBANs
+		{"GB IBAN spaced", "IBAN", "GB82 WEST 1234 5698 7654 32", true},
+		{"DE IBAN compact", "IBAN", "DE89370400440532013000", true},
+		{"bad checksum", "IBAN", "GB83 WEST 1234 5698 7654 32", false},
+		{"wrong length", "IBAN", "DE8937040044053201300", false},
+
+		// SSNs
+		{"valid SSN", "US Social Security Number", "123-45-6789", true},
+		{"area 000", "US Social Security Number", "000-12-3456", false},
+		{"area 666", "US Social Security Number", "666-12-3456", false},
+		{"area 9xx", "US Social Security Number", "912-34-5678", false},
+		{"group 00", "US Social Security Number", "123-00-4567", false},
+		{"advertising SSN", "US Social Security Number", "078-05-1120", false},
+
+		// Email addresses
+		{"real domain", "Email Address", "jane.doe@corp-mail.net", true},
+		{"example.com", "Email Address", "jane@example.com", false},
+		{"example subdomain", "Email Address", "ops@mail.example.org", false},
+	}
+
+	rules := make(map[string]SecretPattern)
+	for _, p := range piiPatterns {
+		rules[p.Name] = p
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, ok := rules[tc.rule]
+			if !ok {
+				t.Fatalf("No PII rule named %q", tc.rule)
+			}
+
+			match := rule.Pattern.FindString(tc.input)
+			if match == "" {
+				if tc.wantKeep {
+					t.Fatalf("Pattern %q did not match %q", tc.rule, tc.input)
+				}
+				return
+			}
+
+			f := Finding{Match: match, Category: CategoryPII}
+			if kept := rule.Inspect(&f); kept != tc.wantKeep {
+				t.Errorf("%s inspector kept %q = %v, want %v", tc.rule, tc.input, kept, tc.wantKeep)
+			}
+		})
+	}
+}
+
+// =============================================================================
+// TEST: PII Opt-In
+// Tests that PII rules only run when enabled and carry the PII category
+// =============================================================================
+
+func TestPIIOptIn(t *testing.T) {
+	path := writeTestFile(t, "customers.csv",
+		"name,email,card\n"+
+			"Jane,jane.doe@corp-mail.net,4111 1111 1111 1111\n"+
+			"token=ghp_"+"1234567890abcdefghijklmnopqrstuvwxyz\n")
+
+	defaults := AnalyzeFile(path)
+	for _, f := range defaults {
+		if f.Category == CategoryPII {
+			t.Errorf("Default scan reported PII finding %q", f.Type)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.EnablePII = true
+	findings := AnalyzeFileWithOptions(path, opts)
+
+	piiTypes := make(map[string]bool)
+	for _, f := range findings {
+		switch f.Category {
+		case CategoryPII:
+			piiTypes[f.Type] = true
+		case CategorySecret:
+		default:
+			t.Errorf("Finding %q has unexpected category %q", f.Type, f.Category)
+		}
+	}
+
+	for _, want := range []string{"Credit Card Number", "Email Address"} {
+		if !piiTypes[want] {
+			t.Errorf("Expected PII finding %q with EnablePII, got %v", want, piiTypes)
+		}
+	}
+}
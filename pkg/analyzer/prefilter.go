@@ -0,0 +1,187 @@
+package analyzer
+
+import (
+	"strings"
+	"sync"
+)
+
+// ruleSet is a list of enabled rules together with a keyword index that
+// selects the rules worth running on a given line.
+type ruleSet struct {
+	patterns []SecretPattern
+	matcher  *keywordMatcher // nil runs every rule on every line
+	anchored []bool          // rule has keywords and only runs when one is seen
+	exact    []int           // anchored rules whose keywords are case-sensitive
+}
+
+// ruleSetCache holds one compiled ruleSet per distinct Options.
+var ruleSetCache sync.Map
+
+// rules returns the compiled rule set for o, building it on first use.
+func (o Options) rules() *ruleSet {
+	key := strings.Join(o.EnabledProviders, ",") + "|" + strings.Join(o.DisabledProviders, ",")
+	if o.EnablePII {
+		key += "|pii"
+	}
+
+	if rs, ok := ruleSetCache.Load(key); ok {
+		return rs.(*ruleSet)
+	}
+	rs, _ := ruleSetCache.LoadOrStore(key, newRuleSet(o.patterns()))
+	return rs.(*ruleSet)
+}
+
+func newRuleSet(patterns []SecretPattern) *ruleSet {
+	rs := &ruleSet{
+		patterns: patterns,
+		matcher:  newKeywordMatcher(patterns),
+		anchored: make([]bool, len(patterns)),
+	}
+	for i, p := range patterns {
+		rs.anchored[i] = len(p.Keywords) > 0
+		if rs.anchored[i] && p.CaseSensitive {
+			rs.exact = append(rs.exact, i)
+		}
+	}
+	return rs
+}
+
+// candidates marks in active the rules that should run on text: every
+// rule without keywords, plus the rules whose keywords occur in text.
+func (rs *ruleSet) candidates(text string, active []bool) {
+	for i := range active {
+		active[i] = !rs.anchored[i] || rs.matcher == nil
+	}
+	if rs.matcher == nil {
+		return
+	}
+	rs.matcher.mark(text, active)
+
+	// The matcher folds case; confirm the keywords that must not.
+	for _, i := range rs.exact {
+		if active[i] {
+			active[i] = containsAny(text, rs.patterns[i].Keywords)
+		}
+	}
+}
+
+func containsAny(text string, keywords []string) bool {
+	for _, kw := range keywords {
+		if strings.Contains(text, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// keywordMatcher is an Aho-Corasick automaton over the rules' keywords.
+// It finds every keyword in a single pass over the text, folding ASCII
+// letters to lowercase as it goes; case-sensitive keywords are folded too
+// and confirmed by ruleSet.candidates.
+type keywordMatcher struct {
+	classes [256]uint8 // byte to alphabet class; 0 is "not in any keyword"
+	width   int        // number of classes
+	next    []int32    // transition table: next[state*width+class]
+	outputs [][]int    // rule indices whose keyword ends at each state
+}
+
+func newKeywordMatcher(patterns []SecretPattern) *keywordMatcher {
+	m := &keywordMatcher{width: 1}
+
+	for _, p := range patterns {
+		for _, kw := range p.Keywords {
+			kw = strings.ToLower(kw)
+			for i := 0; i < len(kw); i++ {
+				c := kw[i]
+				if m.classes[c] != 0 {
+					continue
+				}
+				m.classes[c] = uint8(m.width)
+				if c >= 'a' && c <= 'z' {
+					m.classes[c-'a'+'A'] = uint8(m.width)
+				}
+				m.width++
+			}
+		}
+	}
+
+	// Build the trie; -1 marks a missing edge.
+	m.next = make([]int32, 0, m.width*64)
+	m.outputs = make([][]int, 0, 64)
+	addState := func() int32 {
+		for c := 0; c < m.width; c++ {
+			m.next = append(m.next, -1)
+		}
+		m.outputs = append(m.outputs, nil)
+		return int32(len(m.outputs) - 1)
+	}
+	addState()
+
+	for ruleIndex, p := range patterns {
+		for _, kw := range p.Keywords {
+			kw = strings.ToLower(kw)
+			state := int32(0)
+			for i := 0; i < len(kw); i++ {
+				edge := int(state)*m.width + int(m.classes[kw[i]])
+				if m.next[edge] < 0 {
+					child := addState()
+					m.next[edge] = child
+				}
+				state = m.next[edge]
+			}
+			m.outputs[state] = appendUnique(m.outputs[state], ruleIndex)
+		}
+	}
+
+	// Turn the trie into a DFA: missing edges follow the failure links and
+	// each state inherits the outputs of its failure state.
+	fail := make([]int32, len(m.outputs))
+	queue := make([]int32, 0, len(m.outputs))
+	for c := 0; c < m.width; c++ {
+		if child := m.next[c]; child > 0 {
+			queue = append(queue, child)
+		} else {
+			m.next[c] = 0
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		for c := 0; c < m.width; c++ {
+			edge := int(state)*m.width + c
+			target := m.next[int(fail[state])*m.width+c]
+			child := m.next[edge]
+			if child < 0 {
+				m.next[edge] = target
+				continue
+			}
+			fail[child] = target
+			for _, r := range m.outputs[target] {
+				m.outputs[child] = appendUnique(m.outputs[child], r)
+			}
+			queue = append(queue, child)
+		}
+	}
+
+	return m
+}
+
+// mark sets active[i] for every rule i whose keyword occurs in text.
+func (m *keywordMatcher) mark(text string, active []bool) {
+	state := int32(0)
+	for i := 0; i < len(text); i++ {
+		state = m.next[int(state)*m.width+int(m.classes[text[i]])]
+		for _, r := range m.outputs[state] {
+			active[r] = true
+		}
+	}
+}
+
+func appendUnique(list []int, v int) []int {
+	for _, existing := range list {
+		if existing == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
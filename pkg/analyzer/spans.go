@@ -0,0 +1,86 @@
+package analyzer
+
+// maxJSONLines bounds how far the enclosing JSON object of a finding is
+// searched for in either direction.
+const maxJSONLines = 200
+
+// jsonBlobTypes are finding types whose secret is the whole JSON document
+// around the matched line, not just the line itself.
+var jsonBlobTypes = map[string]bool{
+	"GCP Service Account Key": true,
+}
+
+// expandJSONSpans widens findings of jsonBlobTypes to the braces of the
+// JSON object that contains them.
+func expandJSONSpans(lines []string, findings []Finding) []Finding {
+	for i := range findings {
+		f := &findings[i]
+		if !jsonBlobTypes[f.Type] || f.LineNumber < 1 || f.LineNumber > len(lines) {
+			continue
+		}
+
+		startLine, startCol, ok := openingBrace(lines, f.LineNumber-1, f.Column)
+		if !ok {
+			continue
+		}
+		endLine, endCol, ok := closingBrace(lines, startLine, startCol)
+		if !ok {
+			continue
+		}
+
+		f.LineNumber, f.Column = startLine+1, startCol
+		f.EndLine, f.EndColumn = endLine+1, endCol+1
+	}
+
+	return findings
+}
+
+// openingBrace walks backwards from lines[line][column] to the unmatched
+// '{' that opens the enclosing object.
+func openingBrace(lines []string, line, column int) (int, int, bool) {
+	depth := 0
+	for i := line; i >= 0 && i > line-maxJSONLines; i-- {
+		text := lines[i]
+		from := len(text) - 1
+		if i == line {
+			from = min(column, len(text)) - 1
+		}
+		for j := from; j >= 0; j-- {
+			switch text[j] {
+			case '}':
+				depth++
+			case '{':
+				if depth == 0 {
+					return i, j, true
+				}
+				depth--
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// closingBrace walks forwards from the '{' at lines[line][column] to the
+// '}' that closes it.
+func closingBrace(lines []string, line, column int) (int, int, bool) {
+	depth := 0
+	for i := line; i < len(lines) && i < line+maxJSONLines; i++ {
+		text := lines[i]
+		from := 0
+		if i == line {
+			from = column
+		}
+		for j := from; j < len(text); j++ {
+			switch text[j] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+				if depth == 0 {
+					return i, j, true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
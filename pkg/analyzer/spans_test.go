@@ -0,0 +1,49 @@
+package analyzer
+
+import "testing"
+
+// =============================================================================
+// TEST: Multi-Line Spans
+// Tests that block secrets report the full range of lines they occupy
+// =============================================================================
+
+func TestExpandJSONSpans(t *testing.T) {
+	path := writeTestFile(t, "credentials.json", "{\n"+
+		"  \"type\": \"service_account\",\n"+
+		"  \"project_id\": \"prod\",\n"+
+		"  \"client\": {\"id\": \"123\"}\n"+
+		"}\n")
+
+	keys := findingsOfType(AnalyzeFile(path), "GCP Service Account Key")
+	if len(keys) != 1 {
+		t.Fatalf("Expected one service account finding, got %+v", keys)
+	}
+
+	f := keys[0]
+	if f.LineNumber != 1 || f.Column != 0 || f.EndLine != 5 || f.EndColumn != 1 {
+		t.Errorf("Span = %d:%d-%d:%d, want 1:0-5:1", f.LineNumber, f.Column, f.EndLine, f.EndColumn)
+	}
+}
+
+func TestSingleLineFindingsEndOnTheirLine(t *testing.T) {
+	path := writeTestFile(t, "config.env", "GITLAB="+fakeToken("glpat-", charsAlnum, 20)+"\n")
+
+	for _, f := range AnalyzeFile(path) {
+		if f.EndLine != f.LineNumber || f.MultiLine() {
+			t.Errorf("%s: EndLine = %d, want %d", f.Type, f.EndLine, f.LineNumber)
+		}
+	}
+}
+
+func TestSpansOverlapMultiLine(t *testing.T) {
+	block := Finding{LineNumber: 1, EndLine: 3, Column: 10, EndColumn: 2}
+	after := Finding{LineNumber: 1, EndLine: 1, Column: 30, EndColumn: 40}
+	before := Finding{LineNumber: 1, EndLine: 1, Column: 0, EndColumn: 5}
+
+	if !spansOverlap(block, after) {
+		t.Error("A multi-line finding should cover the rest of its first line")
+	}
+	if spansOverlap(block, before) {
+		t.Error("A multi-line finding should not cover text before its start column")
+	}
+}
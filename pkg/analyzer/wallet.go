@@ -0,0 +1,242 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+)
+
+// bip39WordCounts are the mnemonic lengths allowed by BIP39, longest first.
+var bip39WordCounts = []int{24, 21, 18, 15, 12}
+
+// bip39Index maps each BIP39 word to its 11-bit value.
+var bip39Index = func() map[string]int {
+	index := make(map[string]int, len(bip39English))
+	for i, word := range bip39English {
+		index[word] = i
+	}
+	return index
+}()
+
+// secp256k1N is the order of the secp256k1 group. Valid private keys for
+// Bitcoin and Ethereum lie in [1, N-1].
+var secp256k1N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// WIF version bytes.
+const (
+	wifMainnet = 0x80
+	wifTestnet = 0xef
+)
+
+var lowercaseWord = regexp.MustCompile(`[a-z]+`)
+
+// walletPatterns detect wallet seed phrases and raw private keys. The
+// candidates are broad; each Inspect hook validates the checksum or key
+// range before a finding is kept.
+var walletPatterns = []SecretPattern{
+	{Name: "BIP39 Seed Phrase", Pattern: regexp.MustCompile(`\b[a-z]{3,8}(?:[ \t]+[a-z]{3,8}){11,}\b`), Severity: "HIGH", Provider: "wallet",
+		Inspect:  inspectMnemonic,
+		Examples: []string{"seed: " + strings.Repeat("abandon ", 11) + "about"}},
+	{Name: "Bitcoin WIF Private Key", Pattern: regexp.MustCompile(`\b[59KLc][1-9A-HJ-NP-Za-km-z]{50,51}\b`), Severity: "HIGH", Provider: "wallet",
+		Inspect:  inspectWIF,
+		Examples: []string{"wif=" + exampleWIF(wifMainnet, true), exampleWIF(wifMainnet, false), exampleWIF(wifTestnet, true)}},
+	{Name: "Ethereum Private Key", Pattern: regexp.MustCompile(`(?i)(?:priv(?:ate)?|wallet|signer|deployer|eth|account)[_ .-]?key['"]?\s*[:=]\s*['"]?(?:0x)?[0-9a-f]{64}\b`), Severity: "HIGH", Provider: "wallet",
+		Keywords: []string{"priv", "wallet", "signer", "deployer", "eth", "account"},
+		Inspect:  inspectEthereumKey,
+		Examples: []string{"PRIVATE_KEY=0x" + fakeToken("", charsHex, 64), `"deployerKey": "` + fakeToken("", charsHex, 64) + `"`}},
+}
+
+// inspectMnemonic looks for the longest run of BIP39 words inside the
+// candidate that has a valid length and checksum, and narrows the finding
+// to that run.
+func inspectMnemonic(f *Finding) bool {
+	spans := lowercaseWord.FindAllStringIndex(f.Match, -1)
+	indices := make([]int, len(spans))
+	for i, span := range spans {
+		idx, ok := bip39Index[f.Match[span[0]:span[1]]]
+		if !ok {
+			idx = -1
+		}
+		indices[i] = idx
+	}
+
+	for _, size := range bip39WordCounts {
+		for start := 0; start+size <= len(indices); start++ {
+			if !validMnemonic(indices[start : start+size]) {
+				continue
+			}
+
+			from, to := spans[start][0], spans[start+size-1][1]
+			f.Column += from
+			f.EndColumn = f.Column + (to - from)
+			f.Match = f.Match[from:to]
+			f.Confidence = "HIGH"
+			f.Details = map[string]string{"words": fmt.Sprintf("%d", size)}
+			return true
+		}
+	}
+	return false
+}
+
+// validMnemonic reports whether the word indices form a BIP39 mnemonic:
+// every word is in the list and the trailing checksum bits equal the
+// leading bits of SHA-256 over the entropy.
+func validMnemonic(indices []int) bool {
+	bits := new(big.Int)
+	for _, idx := range indices {
+		if idx < 0 {
+			return false
+		}
+		bits.Lsh(bits, 11)
+		bits.Or(bits, big.NewInt(int64(idx)))
+	}
+
+	totalBits := len(indices) * 11
+	checksumBits := totalBits / 33
+	entropyBytes := (totalBits - checksumBits) / 8
+
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1)).Uint64()
+	entropy := new(big.Int).Rsh(bits, uint(checksumBits)).FillBytes(make([]byte, entropyBytes))
+
+	sum := sha256.Sum256(entropy)
+	return uint64(sum[0]>>(8-checksumBits)) == checksum
+}
+
+// inspectWIF keeps Wallet Import Format strings whose Base58Check checksum,
+// version byte and key range are valid.
+func inspectWIF(f *Finding) bool {
+	payload, ok := base58CheckDecode(f.Match)
+	if !ok || len(payload) < 33 {
+		return false
+	}
+
+	network := ""
+	switch payload[0] {
+	case wifMainnet:
+		network = "mainnet"
+	case wifTestnet:
+		network = "testnet"
+	default:
+		return false
+	}
+
+	key := payload[1:]
+	compressed := false
+	switch {
+	case len(key) == 32:
+	case len(key) == 33 && key[32] == 0x01:
+		key, compressed = key[:32], true
+	default:
+		return false
+	}
+
+	if !validSecp256k1Key(key) {
+		return false
+	}
+
+	f.Confidence = "HIGH"
+	f.Details = map[string]string{
+		"network":    network,
+		"compressed": fmt.Sprintf("%t", compressed),
+	}
+	return true
+}
+
+// inspectEthereumKey keeps hex keys inside the secp256k1 range and drops
+// low-variety placeholders such as repeated digits.
+func inspectEthereumKey(f *Finding) bool {
+	hexKey := strings.ToLower(f.Match[len(f.Match)-64:])
+
+	distinct := make(map[rune]bool)
+	for _, c := range hexKey {
+		distinct[c] = true
+	}
+	if len(distinct) < 6 {
+		return false
+	}
+
+	key, ok := new(big.Int).SetString(hexKey, 16)
+	if !ok || !validSecp256k1Key(key.FillBytes(make([]byte, 32))) {
+		return false
+	}
+
+	f.Confidence = "HIGH"
+	return true
+}
+
+func validSecp256k1Key(key []byte) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() > 0 && k.Cmp(secp256k1N) < 0
+}
+
+// base58CheckDecode decodes s and verifies its four-byte double SHA-256
+// checksum, returning the payload without the checksum.
+func base58CheckDecode(s string) ([]byte, bool) {
+	n := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range s {
+		digit := strings.IndexRune(base58Alphabet, c)
+		if digit < 0 {
+			return nil, false
+		}
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+
+	decoded := n.Bytes()
+	for i := 0; i < len(s) && s[i] == '1'; i++ {
+		decoded = append([]byte{0}, decoded...)
+	}
+	if len(decoded) < 5 {
+		return nil, false
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if string(second[:4]) != string(checksum) {
+		return nil, false
+	}
+	return payload, true
+}
+
+func base58CheckEncode(payload []byte) string {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	data := append(append([]byte{}, payload...), second[:4]...)
+
+	n := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var encoded []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, '1')
+	}
+
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// exampleWIF builds a synthetic WIF test vector from a fixed key.
+func exampleWIF(version byte, compressed bool) string {
+	key := sha256.Sum256([]byte("kyubisweep example key"))
+	payload := append([]byte{version}, key[:]...)
+	if compressed {
+		payload = append(payload, 0x01)
+	}
+	return base58CheckEncode(payload)
+}
@@ -0,0 +1,145 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+)
+
+// =============================================================================
+// TEST: Wallet Detectors
+// Tests BIP39 checksums, WIF decoding and Ethereum key range checks
+// =============================================================================
+
+func TestInspectMnemonic(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantKeep  bool
+		wantWords string
+		wantMatch string
+	}{
+		{"12 words", strings.Repeat("abandon ", 11) + "about", true, "12", strings.Repeat("abandon ", 11) + "about"},
+		{"24 words", strings.Repeat("abandon ", 23) + "art", true, "24", strings.Repeat("abandon ", 23) + "art"},
+		{"leading prose", "my seed is " + strings.Repeat("abandon ", 11) + "about", true, "12", strings.Repeat("abandon ", 11) + "about"},
+		{"bad checksum", strings.Repeat("abandon ", 12), false, "", ""},
+		{"word not in list", strings.Repeat("abandon ", 10) + "kyubi about", false, "", ""},
+		{"plain prose", "the quick brown fox jumps over the lazy dog and then runs away home", false, "", ""},
+	}
+
+	rule := walletRule(t, "BIP39 Seed Phrase")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			loc := rule.Pattern.FindStringIndex(tc.input)
+			if loc == nil {
+				if tc.wantKeep {
+					t.Fatalf("Pattern did not match %q", tc.input)
+				}
+				return
+			}
+
+			f := Finding{Match: tc.input[loc[0]:loc[1]], Column: loc[0], EndColumn: loc[1]}
+			kept := inspectMnemonic(&f)
+			if kept != tc.wantKeep {
+				t.Fatalf("inspectMnemonic(%q) kept=%v, want %v", tc.input, kept, tc.wantKeep)
+			}
+			if !kept {
+				return
+			}
+
+			if f.Match != tc.wantMatch {
+				t.Errorf("Match = %q, want %q", f.Match, tc.wantMatch)
+			}
+			if tc.input[f.Column:f.EndColumn] != f.Match {
+				t.Errorf("Columns %d-%d do not cover the match", f.Column, f.EndColumn)
+			}
+			if f.Details["words"] != tc.wantWords {
+				t.Errorf("words = %q, want %q", f.Details["words"], tc.wantWords)
+			}
+		})
+	}
+}
+
+func TestInspectWIF(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          string
+		wantKeep       bool
+		wantNetwork    string
+		wantCompressed string
+	}{
+		{"mainnet uncompressed", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ", true, "mainnet", "false"},
+		{"mainnet compressed", exampleWIF(wifMainnet, true), true, "mainnet", "true"},
+		{"testnet compressed", exampleWIF(wifTestnet, true), true, "testnet", "true"},
+		{"bad checksum", "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTK", false, "", ""},
+		{"wrong version", base58CheckEncode(append([]byte{0x00}, make([]byte, 32)...)), false, "", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := Finding{Match: tc.input}
+			kept := inspectWIF(&f)
+			if kept != tc.wantKeep {
+				t.Fatalf("inspectWIF(%q) kept=%v, want %v", tc.input, kept, tc.wantKeep)
+			}
+			if !kept {
+				return
+			}
+			if f.Details["network"] != tc.wantNetwork || f.Details["compressed"] != tc.wantCompressed {
+				t.Errorf("Details = %v, want network=%s compressed=%s", f.Details, tc.wantNetwork, tc.wantCompressed)
+			}
+		})
+	}
+}
+
+func TestInspectEthereumKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantKeep bool
+	}{
+		{"env var", "PRIVATE_KEY=0x" + fakeToken("", charsHex, 64), true},
+		{"JSON field", `"signerKey": "` + fakeToken("", charsHex, 64) + `"`, true},
+		{"repeated digits", "PRIVATE_KEY=0x" + strings.Repeat("ab", 32), false},
+		{"zero key", "PRIVATE_KEY=" + strings.Repeat("0", 64), false},
+		{"above curve order", "PRIVATE_KEY=fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364142", false},
+	}
+
+	rule := walletRule(t, "Ethereum Private Key")
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			match := rule.Pattern.FindString(tc.input)
+			if match == "" {
+				t.Fatalf("Pattern did not match %q", tc.input)
+			}
+			f := Finding{Match: match}
+			if kept := inspectEthereumKey(&f); kept != tc.wantKeep {
+				t.Errorf("inspectEthereumKey(%q) kept=%v, want %v", tc.input, kept, tc.wantKeep)
+			}
+		})
+	}
+}
+
+func TestAnalyzeFileReportsWalletKeys(t *testing.T) {
+	path := writeTestFile(t, "wallet.txt",
+		"seed: "+strings.Repeat("abandon ", 11)+"about\n"+
+			"wif="+exampleWIF(wifMainnet, true)+"\n"+
+			"ETH_PRIVATE_KEY=0x"+fakeToken("", charsHex, 64)+"\n")
+
+	findings := AnalyzeFile(path)
+	for _, want := range []string{"BIP39 Seed Phrase", "Bitcoin WIF Private Key", "Ethereum Private Key"} {
+		if len(findingsOfType(findings, want)) != 1 {
+			t.Errorf("Expected one %q finding, got %+v", want, findings)
+		}
+	}
+}
+
+func walletRule(t *testing.T, name string) SecretPattern {
+	t.Helper()
+	for _, rule := range walletPatterns {
+		if rule.Name == name {
+			return rule
+		}
+	}
+	t.Fatalf("No wallet rule named %q", name)
+	return SecretPattern{}
+}
@@ -0,0 +1,150 @@
+// Package baseline records the findings already known in a codebase so that
+// later scans only report new ones. A baseline stores fingerprints, never the
+// secrets themselves, and file paths relative to the baseline file, so it can
+// be committed alongside the code it describes.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// DefaultPath is the baseline file used when none is given.
+const DefaultPath = ".kyubisweep-baseline.json"
+
+// formatVersion is bumped when the fingerprint scheme changes.
+const formatVersion = 1
+
+// Entry identifies one accepted finding.
+type Entry struct {
+	File        string `json:"file"` // slash-separated, relative to the baseline file
+	Type        string `json:"type"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Baseline is a set of accepted findings.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"entries"`
+
+	dir   string // directory entry paths are relative to
+	known map[Entry]bool
+}
+
+// Write records findings as the baseline at path, replacing any previous
+// one. Line numbers are not recorded, so moving a secret within its file
+// does not make it new again.
+func Write(path string, findings []analyzer.Finding) (*Baseline, error) {
+	dir, err := baseDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Baseline{Version: formatVersion, dir: dir, known: make(map[Entry]bool)}
+	for _, f := range findings {
+		entry := b.entry(f)
+		if !b.known[entry] {
+			b.known[entry] = true
+			b.Entries = append(b.Entries, entry)
+		}
+	}
+	sort.Slice(b.Entries, func(i, j int) bool {
+		if b.Entries[i].File != b.Entries[j].File {
+			return b.Entries[i].File < b.Entries[j].File
+		}
+		if b.Entries[i].Type != b.Entries[j].Type {
+			return b.Entries[i].Type < b.Entries[j].Type
+		}
+		return b.Entries[i].Fingerprint < b.Entries[j].Fingerprint
+	})
+
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Load reads the baseline at path.
+func Load(path string) (*Baseline, error) {
+	dir, err := baseDir(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Baseline{dir: dir}
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, fmt.Errorf("corrupt baseline %s: %w", path, err)
+	}
+	if b.Version != formatVersion {
+		return nil, fmt.Errorf("baseline %s has version %d, expected %d; recreate it with `kyubisweep baseline`", path, b.Version, formatVersion)
+	}
+
+	b.known = make(map[Entry]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		b.known[entry] = true
+	}
+	return b, nil
+}
+
+// Len returns the number of accepted findings.
+func (b *Baseline) Len() int {
+	return len(b.Entries)
+}
+
+// Contains reports whether f is an accepted finding.
+func (b *Baseline) Contains(f analyzer.Finding) bool {
+	return b.known[b.entry(f)]
+}
+
+// Filter returns the findings that are not in the baseline, and how many
+// were suppressed.
+func (b *Baseline) Filter(findings []analyzer.Finding) ([]analyzer.Finding, int) {
+	kept := make([]analyzer.Finding, 0, len(findings))
+	for _, f := range findings {
+		if !b.Contains(f) {
+			kept = append(kept, f)
+		}
+	}
+	return kept, len(findings) - len(kept)
+}
+
+// entry returns the baseline entry for f.
+func (b *Baseline) entry(f analyzer.Finding) Entry {
+	file := f.FilePath
+	if filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(b.dir, file); err == nil {
+			file = rel
+		}
+	}
+	return Entry{File: filepath.ToSlash(file), Type: f.Type, Fingerprint: fingerprint(f)}
+}
+
+// fingerprint hashes the type and matched value of f.
+func fingerprint(f analyzer.Finding) string {
+	sum := sha256.Sum256([]byte(f.Type + "\x00" + f.Match))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// baseDir returns the absolute directory of the baseline file at path.
+func baseDir(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(abs), nil
+}
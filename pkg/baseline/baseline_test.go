@@ -0,0 +1,98 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// =============================================================================
+// TEST: Baselines
+// Tests writing, loading and filtering against a baseline in a temp directory
+// =============================================================================
+
+func TestBaselineFiltersKnownFindings(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, DefaultPath)
+
+	aws := analyzer.Finding{FilePath: filepath.Join(dir, "app", "config.env"), LineNumber: 3, Type: "AWS Access Key ID", Match: "AKIAZ7Q3M4N5P6R2S8TX"}
+	if _, err := Write(path, []analyzer.Finding{aws, aws}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), aws.Match) {
+		t.Error("The baseline file must not contain the secret")
+	}
+	if !strings.Contains(string(data), `"file": "app/config.env"`) {
+		t.Errorf("Expected a path relative to the baseline file, got:\n%s", data)
+	}
+
+	b, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if b.Len() != 1 {
+		t.Errorf("Expected duplicate findings to be stored once, got %d entries", b.Len())
+	}
+
+	moved := aws
+	moved.LineNumber = 40
+	otherFile := aws
+	otherFile.FilePath = filepath.Join(dir, "app", "prod.env")
+	rotated := aws
+	rotated.Match = "AKIAQ2W3E4R5T6Y7U8I9"
+
+	tests := []struct {
+		name    string
+		finding analyzer.Finding
+		known   bool
+	}{
+		{"same finding", aws, true},
+		{"moved within file", moved, true},
+		{"same secret in another file", otherFile, false},
+		{"new secret", rotated, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := b.Contains(tc.finding); got != tc.known {
+				t.Errorf("Contains() = %v, want %v", got, tc.known)
+			}
+		})
+	}
+
+	kept, suppressed := b.Filter([]analyzer.Finding{aws, rotated, moved})
+	if suppressed != 2 || len(kept) != 1 || kept[0].Match != rotated.Match {
+		t.Errorf("Filter kept %+v and suppressed %d, want only the new secret kept", kept, suppressed)
+	}
+}
+
+func TestLoadRejectsBadBaselines(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"corrupt", "{not json"},
+		{"wrong version", `{"version": 99, "entries": []}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.name+".json")
+			os.WriteFile(path, []byte(tc.content), 0644)
+			if _, err := Load(path); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("Expected an error for a missing baseline")
+	}
+}
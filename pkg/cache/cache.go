@@ -0,0 +1,330 @@
+// Package cache remembers the findings of previously scanned files so that
+// rescans only analyze files whose content changed. Entries are keyed by
+// path, size, modification time and a SHA-256 of the content, and the whole
+// cache is tied to the analyzer's rules version.
+//
+// The cache never stores the matched secrets in readable form. Each finding
+// keeps its position and a fingerprint of the bytes it spans; its match,
+// details and components are sealed with a key derived from the file's
+// content, so only someone holding the unchanged file can open them. The
+// file is still written with owner-only permissions, as positions and paths
+// say where secrets are.
+package cache
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// Entry is the cached result for one file.
+type Entry struct {
+	Size     int64           `json:"size"`
+	ModTime  int64           `json:"mod_time"` // nanoseconds since the Unix epoch
+	SHA256   string          `json:"sha256"`
+	Findings []cachedFinding `json:"findings"`
+}
+
+// cachedFinding is a finding without its secret. Match, Details and
+// Related are cleared and kept in Sealed instead. Fingerprint hashes the
+// bytes of the file the finding spans, which must be unchanged on a hit.
+type cachedFinding struct {
+	analyzer.Finding
+	Fingerprint string `json:"fingerprint"`
+	Sealed      []byte `json:"sealed"`
+}
+
+// sealedParts are the fields of a finding that may hold secrets.
+type sealedParts struct {
+	Match   string              `json:"match"`
+	Details map[string]string   `json:"details,omitempty"`
+	Related []analyzer.Location `json:"related,omitempty"`
+}
+
+// file is the on-disk layout of a cache.
+type file struct {
+	RulesVersion string            `json:"rules_version"`
+	Files        map[string]*Entry `json:"files"`
+}
+
+// Cache holds cached findings for a single rules version. It is safe for
+// concurrent use.
+type Cache struct {
+	path    string
+	absPath string
+	version string
+
+	mu      sync.Mutex
+	entries map[string]*Entry
+	hits    int
+}
+
+// Open loads the cache stored at path for the given rules version. A
+// missing or unreadable cache file, or one written for another rules
+// version, yields an empty cache.
+func Open(path, rulesVersion string) (*Cache, error) {
+	c := &Cache{path: path, version: rulesVersion, entries: make(map[string]*Entry)}
+	if abs, err := filepath.Abs(path); err == nil {
+		c.absPath = abs
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	var stored file
+	if err := json.Unmarshal(data, &stored); err != nil || stored.RulesVersion != rulesVersion {
+		return c, nil
+	}
+	for p, e := range stored.Files {
+		if e != nil {
+			c.entries[p] = e
+		}
+	}
+	return c, nil
+}
+
+// AnalyzeFile returns the findings for filePath, from the cache when the
+// file is unchanged and from a fresh analysis otherwise. hit reports
+// whether the cached findings were used.
+//
+// A file whose size and modification time match its entry is trusted
+// without hashing it; otherwise its content hash decides. Files with
+// findings are read on a hit to unseal their matches, and are analyzed
+// again if that fails.
+func (c *Cache) AnalyzeFile(ctx context.Context, filePath string, opts analyzer.Options) (findings []analyzer.Finding, hit bool, err error) {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	if info.Size() > analyzer.MaxFileSize {
+		return nil, false, fmt.Errorf("%s: %w", filePath, analyzer.ErrTooLarge)
+	}
+
+	c.mu.Lock()
+	entry := c.entries[filePath]
+	c.mu.Unlock()
+	statHit := entry != nil && entry.Size == info.Size() && entry.ModTime == info.ModTime().UnixNano()
+	if statHit && len(entry.Findings) == 0 {
+		c.hit()
+		return nil, true, nil
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	if entry != nil && (statHit || entry.SHA256 == hash) {
+		if findings, ok := restore(entry.Findings, data); ok {
+			if !statHit {
+				// Touched but not changed: refresh the stat key
+				c.mu.Lock()
+				c.entries[filePath] = &Entry{Size: info.Size(), ModTime: info.ModTime().UnixNano(), SHA256: hash, Findings: entry.Findings}
+				c.mu.Unlock()
+			}
+			c.hit()
+			return findings, true, nil
+		}
+	}
+
+	findings, err = analyzer.AnalyzeFileContext(ctx, filePath, opts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	c.mu.Lock()
+	c.entries[filePath] = &Entry{Size: info.Size(), ModTime: info.ModTime().UnixNano(), SHA256: hash, Findings: strip(findings, data)}
+	c.mu.Unlock()
+	return findings, false, nil
+}
+
+// Owns reports whether path is the cache file or one of the temporary files
+// Save writes next to it, which a scan of the directory holding the cache
+// should skip.
+func (c *Cache) Owns(path string) bool {
+	if c.absPath == "" {
+		return false
+	}
+	name, base := filepath.Base(path), filepath.Base(c.absPath)
+	if tmp, _ := filepath.Match(base+".*.tmp", name); name != base && !tmp {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	return err == nil && filepath.Dir(abs) == filepath.Dir(c.absPath)
+}
+
+func (c *Cache) hit() {
+	c.mu.Lock()
+	c.hits++
+	c.mu.Unlock()
+}
+
+// strip returns findings in their cached form, without the secrets. data
+// is the content of the file they were found in.
+func strip(findings []analyzer.Finding, data []byte) []cachedFinding {
+	starts := lineStarts(data)
+	gcm := contentCipher(data)
+
+	cached := make([]cachedFinding, len(findings))
+	for i, f := range findings {
+		cached[i] = cachedFinding{Finding: f, Fingerprint: spanFingerprint(f, data, starts)}
+		cached[i].Match, cached[i].Details, cached[i].Related = "", nil, nil
+
+		parts, err := json.Marshal(sealedParts{Match: f.Match, Details: f.Details, Related: f.Related})
+		if err != nil {
+			continue
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			continue
+		}
+		cached[i].Sealed = gcm.Seal(nonce, nonce, parts, nil)
+	}
+	return cached
+}
+
+// restore rebuilds findings from their cached form for the file content
+// data. It fails if a finding's span no longer holds the same bytes or its
+// secrets cannot be unsealed.
+func restore(cached []cachedFinding, data []byte) ([]analyzer.Finding, bool) {
+	starts := lineStarts(data)
+	gcm := contentCipher(data)
+
+	findings := make([]analyzer.Finding, len(cached))
+	for i, cf := range cached {
+		if cf.Fingerprint == "" || spanFingerprint(cf.Finding, data, starts) != cf.Fingerprint {
+			return nil, false
+		}
+		if len(cf.Sealed) < gcm.NonceSize() {
+			return nil, false
+		}
+		nonce, sealed := cf.Sealed[:gcm.NonceSize()], cf.Sealed[gcm.NonceSize():]
+		plain, err := gcm.Open(nil, nonce, sealed, nil)
+		if err != nil {
+			return nil, false
+		}
+		var parts sealedParts
+		if err := json.Unmarshal(plain, &parts); err != nil {
+			return nil, false
+		}
+		findings[i] = cf.Finding
+		findings[i].Match, findings[i].Details, findings[i].Related = parts.Match, parts.Details, parts.Related
+	}
+	return findings, true
+}
+
+// contentCipher returns an AES-GCM cipher keyed by data. The key is a hash
+// of the content distinct from the SHA-256 stored in the entry, so the
+// cache alone does not reveal it.
+func contentCipher(data []byte) cipher.AEAD {
+	h := sha256.New()
+	h.Write([]byte("kyubisweep cache key\x00"))
+	h.Write(data)
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		panic(err) // a 32-byte key is always valid
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return gcm
+}
+
+// lineStarts returns the offset of the start of each line in data.
+func lineStarts(data []byte) []int {
+	starts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// spanFingerprint hashes the type of f and the bytes of data it spans, or
+// returns "" if the span lies outside data.
+func spanFingerprint(f analyzer.Finding, data []byte, starts []int) string {
+	offset := func(line, column int) int {
+		if line < 1 || line > len(starts) {
+			return -1
+		}
+		return starts[line-1] + column
+	}
+	from, to := offset(f.LineNumber, f.Column), offset(max(f.EndLine, f.LineNumber), f.EndColumn)
+	if from < 0 || to < from || to > len(data) {
+		return ""
+	}
+	sum := sha256.Sum256(append([]byte(f.Type+"\x00"), data[from:to]...))
+	return hex.EncodeToString(sum[:])
+}
+
+// Hits returns how many lookups were served from the cache since Open.
+func (c *Cache) Hits() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits
+}
+
+// Len returns the number of cached files.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Save writes the cache back to disk, dropping entries for files that no
+// longer exist. The file is replaced atomically.
+func (c *Cache) Save() error {
+	c.mu.Lock()
+	stored := file{RulesVersion: c.version, Files: make(map[string]*Entry, len(c.entries))}
+	for p, e := range c.entries {
+		if _, err := os.Stat(p); err == nil {
+			stored.Files[p] = e
+		}
+	}
+	c.mu.Unlock()
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	if dir := filepath.Dir(c.path); dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("failed to create cache directory: %w", err)
+		}
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write cache: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -1,8 +1,9 @@
 // Package common provides shared constants and utilities used across KyubiSweep.
 package common
 
-// ANSI Escape Codes for terminal colors
-const (
+// ANSI Escape Codes for terminal colors. They are variables so that
+// DisableColor can blank them for plain output.
+var (
 	// Reset
 	ColorReset = "\033[0m"
 
@@ -26,8 +27,25 @@ const (
 	BgBlue   = "\033[44m"
 )
 
+// colorEnabled is cleared by DisableColor.
+var colorEnabled = true
+
+// DisableColor turns off ANSI escape codes for the rest of the process,
+// e.g. for --no-color, NO_COLOR or output that is not a terminal. Call it
+// before anything is printed.
+func DisableColor() {
+	colorEnabled = false
+	ColorReset, ColorBold, ColorDim = "", "", ""
+	ColorRed, ColorGreen, ColorYellow, ColorBlue = "", "", "", ""
+	ColorMagenta, ColorCyan, ColorWhite = "", "", ""
+	BgRed, BgGreen, BgYellow, BgBlue = "", "", "", ""
+}
+
 // Colorize wraps text with ANSI color codes
 func Colorize(text string, color string) string {
+	if !colorEnabled {
+		return text
+	}
 	return color + text + ColorReset
 }
 
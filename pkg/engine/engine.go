@@ -0,0 +1,298 @@
+// Package engine runs a complete secret scan over a directory tree: it walks
+// the tree, analyzes files on a pool of workers and streams the findings to
+// the caller. The CLI is built on it, and other tools can embed it.
+package engine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+	"github.com/tanmayshahane/kyubisweep/pkg/cache"
+	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+)
+
+// Config controls how an Engine scans.
+type Config struct {
+	// Workers is the number of files analyzed in parallel, and the number
+	// of directories read in parallel while walking.
+	Workers int
+
+	// Extensions lists the file extensions (and bare file names) to scan.
+	// Nil uses scanner.DefaultTextExtensions.
+	Extensions map[string]bool
+
+	// Analyzer selects the rules and detectors applied to each file.
+	Analyzer analyzer.Options
+
+	// Filter drops findings for which it returns false. Nil keeps all.
+	Filter func(analyzer.Finding) bool
+
+	// Cache, if set, supplies the findings of unchanged files and records
+	// the findings of analyzed ones. It must have been opened for
+	// Analyzer.RulesVersion(). Saving it is left to the caller. The cache
+	// file itself is never scanned.
+	Cache *cache.Cache
+
+	// OnFile, if set, is called by a worker before it analyzes a file.
+	// It must be safe for concurrent use.
+	OnFile func(workerID int, path string)
+
+	// OnError, if set, is called with each file that could not be read or
+	// analyzed. Such files count in Stats.FilesFailed, not FilesScanned.
+	// It must be safe for concurrent use.
+	OnError func(path string, err error)
+}
+
+// DefaultConfig returns a Config that runs one worker per CPU with the
+// default extensions and every provider enabled.
+func DefaultConfig() Config {
+	return Config{
+		Workers:  runtime.NumCPU(),
+		Analyzer: analyzer.DefaultOptions(),
+	}
+}
+
+// Stats summarizes a finished scan.
+type Stats struct {
+	FilesScanned int
+	Findings     int
+
+	// CacheHits counts the scanned files whose findings came from Cache.
+	CacheHits int
+
+	// Incomplete is set when the scan was cancelled. FilesSkipped then
+	// counts the files that were queued but not analyzed; files the walker
+	// had not reached yet are not counted anywhere.
+	Incomplete   bool
+	FilesSkipped int
+
+	// FilesFailed counts the files that could not be read or analyzed,
+	// e.g. missing --files-from entries or directories given as files.
+	FilesFailed int
+}
+
+// Add accumulates the stats of another scan into s.
+func (s *Stats) Add(other Stats) {
+	s.FilesScanned += other.FilesScanned
+	s.Findings += other.Findings
+	s.CacheHits += other.CacheHits
+	s.FilesSkipped += other.FilesSkipped
+	s.FilesFailed += other.FilesFailed
+	s.Incomplete = s.Incomplete || other.Incomplete
+}
+
+// Engine scans directory trees, file lists and streams. It is safe to run several scans on one
+// Engine at the same time.
+type Engine struct {
+	cfg Config
+}
+
+// New returns an Engine configured by cfg. Empty fields in cfg fall back to
+// DefaultConfig.
+func New(cfg Config) *Engine {
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultConfig().Workers
+	}
+	return &Engine{cfg: cfg}
+}
+
+// Scan walks root and calls fn for every finding that passes the filter.
+// fn is always called from the goroutine that called Scan, so it needs no
+// locking. When ctx is cancelled Scan stops walking and analyzing, still
+// delivers the findings of files already analyzed, and returns stats
+// marked Incomplete along with ctx's error.
+func (e *Engine) Scan(ctx context.Context, root string, fn func(analyzer.Finding)) (Stats, error) {
+	return e.ScanRoots(ctx, []string{root}, fn)
+}
+
+// ScanRoots is like Scan for several roots, which may be directories or
+// files. A file reached from more than one root is analyzed once.
+func (e *Engine) ScanRoots(ctx context.Context, roots []string, fn func(analyzer.Finding)) (Stats, error) {
+	return e.ScanPaths(ctx, roots, nil, fn)
+}
+
+// ScanFiles analyzes exactly the given files, without the walker's
+// extension filter, as when the list comes from another tool. Files larger
+// than analyzer.MaxFileSize are still skipped, as the walker skips them.
+func (e *Engine) ScanFiles(ctx context.Context, files []string, fn func(analyzer.Finding)) (Stats, error) {
+	return e.ScanPaths(ctx, nil, files, fn)
+}
+
+// ScanPaths combines ScanRoots and ScanFiles in one scan. A file that is
+// both listed and reached from a root is analyzed once.
+func (e *Engine) ScanPaths(ctx context.Context, roots, files []string, fn func(analyzer.Finding)) (Stats, error) {
+	return e.scan(ctx, fn, func(filePaths chan<- string) {
+		for _, root := range roots {
+			if scanner.WalkParallel(ctx, root, filePaths, e.cfg.Workers, e.cfg.Extensions) != nil {
+				return
+			}
+		}
+		for _, path := range files {
+			select {
+			case filePaths <- path:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+}
+
+// ScanReader analyzes content read from r as a single file called name,
+// e.g. piped input. The name scopes file-type specific rules. The cache
+// is not used.
+func (e *Engine) ScanReader(ctx context.Context, name string, r io.Reader, fn func(analyzer.Finding)) (Stats, error) {
+	var stats Stats
+	findings, err := analyzer.AnalyzeReader(ctx, name, r, e.cfg.Analyzer)
+	if err != nil {
+		stats.Incomplete = ctx.Err() != nil
+		if !stats.Incomplete {
+			stats.FilesFailed = 1
+		}
+		return stats, err
+	}
+
+	stats.FilesScanned = 1
+	for _, f := range findings {
+		if e.cfg.Filter != nil && !e.cfg.Filter(f) {
+			continue
+		}
+		stats.Findings++
+		fn(f)
+	}
+	return stats, nil
+}
+
+// scan analyzes the files produced by produce on the worker pool. produce
+// sends paths until it is done or ctx is cancelled; duplicate paths are
+// dropped.
+func (e *Engine) scan(ctx context.Context, fn func(analyzer.Finding), produce func(chan<- string)) (Stats, error) {
+	filePaths := make(chan string, 100)
+
+	go func() {
+		defer close(filePaths)
+
+		produced := make(chan string, 100)
+		go func() {
+			produce(produced)
+			close(produced)
+		}()
+
+		seen := make(map[string]bool)
+		for path := range produced {
+			if seen[path] || (e.cfg.Cache != nil && e.cfg.Cache.Owns(path)) {
+				continue
+			}
+			seen[path] = true
+			select {
+			case filePaths <- path:
+			case <-ctx.Done():
+				// Let the producer see the cancellation and finish
+				for range produced {
+				}
+				return
+			}
+		}
+	}()
+
+	results := make(chan []analyzer.Finding, 100)
+	fileCounts := make(chan workerCounts, e.cfg.Workers)
+	var wg sync.WaitGroup
+
+	for i := 0; i < e.cfg.Workers; i++ {
+		wg.Add(1)
+
+		go func(workerID int) {
+			defer wg.Done()
+			var counts workerCounts
+
+			for filePath := range filePaths {
+				// Keep draining after cancellation so the walker can finish.
+				if ctx.Err() != nil {
+					counts.skipped++
+					continue
+				}
+				if e.cfg.OnFile != nil {
+					e.cfg.OnFile(workerID, filePath)
+				}
+
+				findings, hit, err := e.analyze(ctx, filePath)
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					counts.skipped++
+					continue
+				}
+				if errors.Is(err, analyzer.ErrTooLarge) {
+					continue // filtered out, as the walker does
+				}
+				if err != nil {
+					counts.failed++
+					if e.cfg.OnError != nil {
+						e.cfg.OnError(filePath, err)
+					}
+					continue
+				}
+				counts.scanned++
+				if hit {
+					counts.cacheHits++
+				}
+				if len(findings) > 0 {
+					results <- findings
+				}
+			}
+			fileCounts <- counts
+		}(i)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+		close(fileCounts)
+	}()
+
+	var stats Stats
+	for findings := range results {
+		for _, f := range findings {
+			if e.cfg.Filter != nil && !e.cfg.Filter(f) {
+				continue
+			}
+			stats.Findings++
+			fn(f)
+		}
+	}
+	for counts := range fileCounts {
+		stats.FilesScanned += counts.scanned
+		stats.FilesSkipped += counts.skipped
+		stats.CacheHits += counts.cacheHits
+		stats.FilesFailed += counts.failed
+	}
+	stats.Incomplete = ctx.Err() != nil
+
+	return stats, ctx.Err()
+}
+
+// workerCounts are the per-worker tallies merged into Stats.
+type workerCounts struct {
+	scanned, skipped, failed, cacheHits int
+}
+
+// analyze returns the findings for one file, going through the cache when
+// one is configured.
+func (e *Engine) analyze(ctx context.Context, filePath string) ([]analyzer.Finding, bool, error) {
+	if e.cfg.Cache != nil {
+		return e.cfg.Cache.AnalyzeFile(ctx, filePath, e.cfg.Analyzer)
+	}
+	findings, err := analyzer.AnalyzeFileContext(ctx, filePath, e.cfg.Analyzer)
+	return findings, false, err
+}
+
+// Collect runs Scan and returns the findings as a slice.
+func (e *Engine) Collect(ctx context.Context, root string) ([]analyzer.Finding, Stats, error) {
+	var findings []analyzer.Finding
+	stats, err := e.Scan(ctx, root, func(f analyzer.Finding) {
+		findings = append(findings, f)
+	})
+	return findings, stats, err
+}
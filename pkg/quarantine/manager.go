@@ -24,6 +24,7 @@ type MoveResult struct {
 
 // QuarantineFiles moves files containing secrets to a secure target directory.
 // It creates the target directory if it doesn't exist and handles naming collisions.
+// Each move is recorded in the directory's manifest for RestoreFiles.
 //
 // IMPORTANT: This MOVES files (cut/paste), not copies. Original files are removed.
 func QuarantineFiles(filePaths []string, targetDir string) ([]MoveResult, error) {
@@ -66,6 +67,9 @@ func QuarantineFiles(filePaths []string, targetDir string) ([]MoveResult, error)
 		results = append(results, result)
 	}
 
+	if err := recordMoves(targetDir, results); err != nil {
+		return results, fmt.Errorf("files moved but manifest not updated: %w", err)
+	}
 	return results, nil
 }
 
@@ -179,8 +183,17 @@ func ConfirmQuarantine(fileCount int, targetDir string) bool {
 
 // PrintQuarantineResults displays the results of a quarantine operation
 func PrintQuarantineResults(results []MoveResult) {
+	printResults(results, "📦 QUARANTINE RESULTS", "quarantined")
+}
+
+// PrintRestoreResults displays the results of a restore operation
+func PrintRestoreResults(results []MoveResult) {
+	printResults(results, "♻️  RESTORE RESULTS", "restored")
+}
+
+func printResults(results []MoveResult, title, done string) {
 	fmt.Println()
-	fmt.Println(common.Bold("  📦 QUARANTINE RESULTS"))
+	fmt.Println(common.Bold("  " + title))
 	fmt.Println("  ─────────────────────────────────────────────────────────────────────────")
 
 	successCount := 0
@@ -200,7 +213,7 @@ func PrintQuarantineResults(results []MoveResult) {
 
 	fmt.Println()
 	if failCount == 0 {
-		fmt.Printf("  %s Successfully quarantined %d file(s)\n", common.Green("✅"), successCount)
+		fmt.Printf("  %s Successfully %s %d file(s)\n", common.Green("✅"), done, successCount)
 	} else {
 		fmt.Printf("  ⚠️  Moved: %d | Failed: %d\n", successCount, failCount)
 	}
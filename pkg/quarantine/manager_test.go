@@ -241,3 +241,67 @@ func TestQuarantineNonExistentFile(t *testing.T) {
 		t.Error("Moving non-existent file should fail")
 	}
 }
+
+// =============================================================================
+// TEST: RestoreFiles
+// Quarantines real files and moves them back using the vault manifest
+// =============================================================================
+
+func TestRestoreFiles(t *testing.T) {
+	sourceDir := t.TempDir()
+	vaultDir := filepath.Join(t.TempDir(), "vault")
+
+	// Two files with the same name collide in the vault
+	paths := []string{
+		filepath.Join(sourceDir, "api", ".env"),
+		filepath.Join(sourceDir, "web", ".env"),
+		filepath.Join(sourceDir, "keys.json"),
+	}
+	for _, path := range paths {
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte(path), 0644); err != nil {
+			t.Fatalf("Failed to create test file: %v", err)
+		}
+	}
+
+	if _, err := QuarantineFiles(paths, vaultDir); err != nil {
+		t.Fatalf("QuarantineFiles failed: %v", err)
+	}
+	os.RemoveAll(filepath.Join(sourceDir, "web"))
+
+	// Recreate keys.json so its restore is refused
+	os.WriteFile(paths[2], []byte("new"), 0644)
+
+	results, err := RestoreFiles(vaultDir)
+	if err != nil {
+		t.Fatalf("RestoreFiles failed: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+
+	for _, path := range paths[:2] {
+		content, err := os.ReadFile(path)
+		if err != nil || string(content) != path {
+			t.Errorf("Expected %s restored with its own content, got %q, %v", path, content, err)
+		}
+	}
+	if content, _ := os.ReadFile(paths[2]); string(content) != "new" {
+		t.Error("Restore must not overwrite a file that exists again")
+	}
+	if results[2].Success {
+		t.Error("Restoring over an existing file should fail")
+	}
+
+	// Only the failed entry stays in the manifest
+	entries, err := readManifest(vaultDir)
+	if err != nil || len(entries) != 1 || entries[0].OriginalPath != paths[2] {
+		t.Errorf("Expected only keys.json left in the manifest, got %+v, %v", entries, err)
+	}
+}
+
+func TestRestoreFilesWithoutManifest(t *testing.T) {
+	if _, err := RestoreFiles(t.TempDir()); err == nil {
+		t.Error("Expected an error for a vault without a manifest")
+	}
+}
@@ -0,0 +1,120 @@
+package quarantine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestName is the file in a quarantine vault that records where each
+// quarantined file came from, so that RestoreFiles can put it back.
+const ManifestName = ".kyubisweep-manifest.json"
+
+// ManifestEntry records one quarantined file.
+type ManifestEntry struct {
+	OriginalPath string    `json:"original_path"`
+	VaultName    string    `json:"vault_name"` // file name inside the vault
+	MovedAt      time.Time `json:"moved_at"`
+}
+
+// readManifest returns the entries recorded in vaultDir. A vault without a
+// manifest has no entries.
+func readManifest(vaultDir string) ([]ManifestEntry, error) {
+	data, err := os.ReadFile(filepath.Join(vaultDir, ManifestName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ManifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("corrupt manifest %s: %w", ManifestName, err)
+	}
+	return entries, nil
+}
+
+// writeManifest replaces the manifest of vaultDir, removing it when there
+// is nothing left to record.
+func writeManifest(vaultDir string, entries []ManifestEntry) error {
+	path := filepath.Join(vaultDir, ManifestName)
+	if len(entries) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600) // 0600 = owner-only, like the vault
+}
+
+// recordMoves appends the successful moves in results to the manifest of
+// vaultDir.
+func recordMoves(vaultDir string, results []MoveResult) error {
+	entries, err := readManifest(vaultDir)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, r := range results {
+		if r.Success {
+			entries = append(entries, ManifestEntry{
+				OriginalPath: r.OriginalPath,
+				VaultName:    filepath.Base(r.NewPath),
+				MovedAt:      now,
+			})
+		}
+	}
+	return writeManifest(vaultDir, entries)
+}
+
+// RestoreFiles moves every file recorded in the manifest of vaultDir back
+// to its original location. A file whose original path is occupied again is
+// left in the vault and reported as failed; the manifest keeps only the
+// entries that were not restored.
+//
+// In the results, OriginalPath is the file's location in the vault and
+// NewPath the location it was restored to.
+func RestoreFiles(vaultDir string) ([]MoveResult, error) {
+	entries, err := readManifest(vaultDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no quarantine manifest in %s", vaultDir)
+	}
+
+	results := make([]MoveResult, 0, len(entries))
+	remaining := make([]ManifestEntry, 0)
+
+	for _, entry := range entries {
+		result := MoveResult{OriginalPath: filepath.Join(vaultDir, entry.VaultName)}
+
+		if _, err := os.Lstat(entry.OriginalPath); err == nil {
+			result.Error = fmt.Errorf("%s already exists", entry.OriginalPath)
+		} else if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), 0755); err != nil {
+			result.Error = fmt.Errorf("failed to recreate directory: %w", err)
+		} else if err := moveFile(result.OriginalPath, entry.OriginalPath); err != nil {
+			result.Error = err
+		} else {
+			result.Success = true
+			result.NewPath = entry.OriginalPath
+		}
+
+		if !result.Success {
+			remaining = append(remaining, entry)
+		}
+		results = append(results, result)
+	}
+
+	return results, writeManifest(vaultDir, remaining)
+}
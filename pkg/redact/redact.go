@@ -0,0 +1,242 @@
+// Package redact masks secrets in a stream of text, such as CI logs, using
+// the same rules as a scan. Output is written line by line as input arrives.
+package redact
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync/atomic"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// maxBlockLines bounds how many lines of a PEM block are held back waiting
+// for its END line before they are redacted and written anyway.
+const maxBlockLines = 200
+
+// Config controls how a Redactor treats its input.
+type Config struct {
+	// Name is the file name the input is analyzed as, which scopes rules
+	// by file type.
+	Name string
+	// JSON treats each line as a JSON document and only redacts inside
+	// string literals, keys included. Lines that are not valid JSON are
+	// redacted as plain text.
+	JSON bool
+	// Analyzer selects the detection rules.
+	Analyzer analyzer.Options
+}
+
+// DefaultConfig returns the configuration used by `kyubisweep redact`.
+func DefaultConfig() Config {
+	return Config{Name: "stdin", Analyzer: analyzer.DefaultOptions()}
+}
+
+// Redactor masks secrets line by line.
+type Redactor struct {
+	cfg   Config
+	count atomic.Int64
+}
+
+// New returns a Redactor for cfg.
+func New(cfg Config) *Redactor {
+	return &Redactor{cfg: cfg}
+}
+
+// Count returns the number of secrets masked so far. It is safe to call
+// while Stream is running.
+func (r *Redactor) Count() int {
+	return int(r.count.Load())
+}
+
+// Stream copies in to out, masking secrets. Each line is written as soon as
+// it has been checked, and out is flushed whenever no more input is
+// buffered. Lines of a PEM block are held until its END line so that the
+// key body is masked along with its header. Stream returns at EOF, on a
+// read or write error, or when ctx is cancelled.
+func (r *Redactor) Stream(ctx context.Context, in io.Reader, out io.Writer) error {
+	reader := bufio.NewReader(in)
+	writer := bufio.NewWriter(out)
+	defer writer.Flush()
+
+	var block []string
+	flushBlock := func() error {
+		if len(block) == 0 {
+			return nil
+		}
+		text, err := r.Text(ctx, strings.Join(block, ""))
+		block = block[:0]
+		if err != nil {
+			return err
+		}
+		_, err = writer.WriteString(text)
+		return err
+	}
+
+	for {
+		line, readErr := reader.ReadString('\n')
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if line != "" {
+			if !r.cfg.JSON && (len(block) > 0 || strings.Contains(line, "-----BEGIN ")) {
+				block = append(block, line)
+				if strings.Contains(line, "-----END ") || len(block) >= maxBlockLines {
+					if err := flushBlock(); err != nil {
+						return err
+					}
+				}
+			} else {
+				redacted, err := r.Line(ctx, line)
+				if err != nil {
+					return err
+				}
+				if _, err := writer.WriteString(redacted); err != nil {
+					return err
+				}
+			}
+		}
+
+		if readErr != nil {
+			if err := flushBlock(); err != nil {
+				return err
+			}
+			if readErr == io.EOF {
+				return nil
+			}
+			return readErr
+		}
+		if reader.Buffered() == 0 {
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Line masks the secrets in one line of input, including its terminator
+// if present. In JSON mode only the contents of string literals are
+// redacted.
+func (r *Redactor) Line(ctx context.Context, line string) (string, error) {
+	if r.cfg.JSON {
+		body := strings.TrimRight(line, "\r\n")
+		if json.Valid([]byte(body)) {
+			redacted, err := r.jsonStrings(ctx, body)
+			return redacted + line[len(body):], err
+		}
+	}
+	return r.Text(ctx, line)
+}
+
+// Text masks the secrets in text, which may span several lines.
+func (r *Redactor) Text(ctx context.Context, text string) (string, error) {
+	redacted, n, err := analyzer.Redact(ctx, r.cfg.Name, text, r.cfg.Analyzer)
+	r.count.Add(int64(n))
+	return redacted, err
+}
+
+// jsonStrings redacts the string literals in a valid JSON document, keys
+// as well as values, leaving the document's layout untouched. A value is
+// analyzed together with its key, as `"key":"value"`, so that rules keyed
+// on names such as password still apply; only the value is masked.
+func (r *Redactor) jsonStrings(ctx context.Context, doc string) (string, error) {
+	var sb strings.Builder
+	pos := 0
+	key, keyEnd := "", -1
+	for i := 0; i < len(doc); i++ {
+		if doc[i] != '"' {
+			continue
+		}
+		start, end := i, stringEnd(doc, i)
+		literal := doc[start:end]
+		i = end - 1
+
+		var value string
+		if err := json.Unmarshal([]byte(literal), &value); err != nil {
+			continue
+		}
+
+		var redacted string
+		var err error
+		if isKey(doc, end) {
+			key, keyEnd = value, end
+			redacted, err = r.Text(ctx, value)
+		} else if keyEnd >= 0 && strings.TrimSpace(doc[keyEnd:start]) == ":" {
+			redacted, err = r.keyedValue(ctx, key, value)
+		} else {
+			redacted, err = r.Text(ctx, value)
+		}
+		if err != nil {
+			return doc, err
+		}
+		if redacted == value {
+			continue
+		}
+
+		sb.WriteString(doc[pos:start])
+		sb.WriteString(quote(redacted))
+		pos = end
+	}
+	sb.WriteString(doc[pos:])
+	return sb.String(), nil
+}
+
+// keyedValue masks the secrets in the value of key, analyzing them as the
+// compact JSON `"key":"value"` and mapping what is found back onto the
+// value. Secrets found in the key alone are left to the key's own
+// redaction.
+func (r *Redactor) keyedValue(ctx context.Context, key, value string) (string, error) {
+	prefix := `"` + key + `":"`
+	spans, _, err := analyzer.SecretSpans(ctx, r.cfg.Name, prefix+value+`"`, r.cfg.Analyzer)
+	if err != nil {
+		return value, err
+	}
+
+	var inValue []analyzer.Span
+	for _, s := range spans {
+		from, to := max(s.From-len(prefix), 0), min(s.To-len(prefix), len(value))
+		if from < to {
+			inValue = append(inValue, analyzer.Span{From: from, To: to})
+		}
+	}
+	if len(inValue) == 0 {
+		return value, nil
+	}
+	r.count.Add(int64(len(inValue)))
+	return analyzer.MaskSpans(value, inValue), nil
+}
+
+// stringEnd returns the offset just past the closing quote of the JSON
+// string literal that opens at doc[start].
+func stringEnd(doc string, start int) int {
+	for i := start + 1; i < len(doc); i++ {
+		switch doc[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+	return len(doc)
+}
+
+// isKey reports whether the string literal ending at doc[end] is an object
+// key, i.e. is followed by a colon.
+func isKey(doc string, end int) bool {
+	rest := strings.TrimLeft(doc[end:], " \t\r\n")
+	return strings.HasPrefix(rest, ":")
+}
+
+// quote encodes s as a JSON string without escaping HTML characters.
+func quote(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
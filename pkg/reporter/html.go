@@ -0,0 +1,192 @@
+package reporter
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// htmlFinding is the view of one finding rendered by htmlReportTemplate.
+type htmlFinding struct {
+	Index      int
+	Severity   string
+	Type       string
+	FilePath   string
+	Lines      string
+	Match      string
+	Confidence string
+	Details    [][2]string
+	Also       string
+	Context    []htmlContextLine
+}
+
+type htmlContextLine struct {
+	Text   string
+	InSpan bool
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>KyubiSweep Security Audit Report</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem auto; max-width: 960px; color: #1f2328; }
+table { border-collapse: collapse; margin-bottom: 1.5rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.8rem; text-align: left; }
+.finding { border: 1px solid #d0d7de; border-radius: 6px; padding: 0.5rem 1rem; margin-bottom: 1rem; }
+.sev { font-weight: bold; }
+.CRITICAL, .HIGH { color: #cf222e; }
+.MEDIUM { color: #9a6700; }
+.LOW { color: #0969da; }
+.PII { color: #8250df; }
+pre { background: #f6f8fa; padding: 0.5rem; overflow-x: auto; }
+pre .span { background: #fff8c5; }
+</style>
+</head>
+<body>
+<h1>🛡️ KyubiSweep Security Audit Report</h1>
+<p><strong>Scan Time:</strong> {{.Timestamp}}<br>
+<strong>Target:</strong> <code>{{.ScanPath}}</code><br>
+<strong>Files Scanned:</strong> {{.FilesScanned}}<br>
+{{if .FilesFailed}}<strong>Could Not Analyze:</strong> {{.FilesFailed}}<br>
+{{end}}{{if .Baselined}}<strong>Known (in baseline):</strong> {{.Baselined}}<br>
+{{end}}<strong>Duration:</strong> {{.Duration}}</p>
+{{if .Incomplete}}<p class="MEDIUM"><strong>⚠️ Incomplete scan:</strong> interrupted before every file was analyzed. {{.FilesSkipped}} queued files were skipped; the number of files not yet reached is unknown.</p>{{end}}
+
+<h2>Summary</h2>
+<table>
+<tr><th>Severity</th><th>Count</th></tr>
+<tr><td class="CRITICAL">🚨 CRITICAL</td><td>{{.Critical}}</td></tr>
+<tr><td class="HIGH">🔴 HIGH</td><td>{{.High}}</td></tr>
+<tr><td class="MEDIUM">🟡 MEDIUM</td><td>{{.Medium}}</td></tr>
+<tr><td class="LOW">🔵 LOW</td><td>{{.Low}}</td></tr>
+<tr><th>Total</th><th>{{.Total}}</th></tr>
+</table>
+{{if .PII}}
+<h2>Personal Data (PII)</h2>
+<table>
+<tr><th>Type</th><th>Count</th></tr>
+{{range .PII}}<tr><td>{{index . 0}}</td><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{end}}
+{{if .Findings}}<h2>Findings</h2>{{end}}
+{{range .Findings}}
+<div class="finding">
+<h3>{{.Index}}. <span class="sev {{.Severity}}">[{{.Severity}}]</span> {{.Type}}</h3>
+<ul>
+<li><strong>File:</strong> <code>{{.FilePath}}</code></li>
+<li><strong>{{.Lines}}</strong></li>
+<li><strong>Match:</strong> <code>{{.Match}}</code></li>
+{{if .Confidence}}<li><strong>Confidence:</strong> {{.Confidence}}</li>{{end}}
+{{range .Details}}<li><strong>{{index . 0}}:</strong> {{index . 1}}</li>
+{{end}}{{if .Also}}<li><strong>Also Matched:</strong> {{.Also}}</li>{{end}}
+</ul>
+{{if .Context}}<pre>{{range .Context}}<span{{if .InSpan}} class="span"{{end}}>{{.Text}}</span>
+{{end}}</pre>{{end}}
+</div>
+{{end}}
+</body>
+</html>
+`))
+
+// SaveHTMLReport saves a standalone HTML report to outputDir. Captured
+// context is included with secrets redacted.
+func SaveHTMLReport(result ScanResult, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := filepath.Join(outputDir, fmt.Sprintf("kyubisweep_%s.html", timestamp))
+
+	critical, high, medium, low := countBySeverity(result.Findings)
+
+	piiCounts := countPIIByType(result.Findings)
+	pii := make([][2]string, 0, len(piiCounts))
+	for _, name := range sortedKeys(piiCounts) {
+		pii = append(pii, [2]string{name, fmt.Sprintf("%d", piiCounts[name])})
+	}
+
+	findings := make([]htmlFinding, 0, len(result.Findings))
+	for i, f := range result.Findings {
+		findings = append(findings, newHTMLFinding(i+1, f))
+	}
+
+	data := struct {
+		Timestamp, ScanPath, Duration      string
+		FilesScanned, FilesSkipped         int
+		FilesFailed                        int
+		Baselined                          int
+		Incomplete                         bool
+		Critical, High, Medium, Low, Total int
+		PII                                [][2]string
+		Findings                           []htmlFinding
+	}{
+		Timestamp:    timestamp,
+		ScanPath:     result.ScanPath,
+		Duration:     formatDuration(result.EndTime.Sub(result.StartTime)),
+		FilesScanned: result.FilesScanned,
+		FilesSkipped: result.FilesSkipped,
+		FilesFailed:  result.FilesFailed,
+		Baselined:    result.FindingsBaselined,
+		Incomplete:   result.Incomplete,
+		Critical:     critical,
+		High:         high,
+		Medium:       medium,
+		Low:          low,
+		Total:        critical + high + medium + low,
+		PII:          pii,
+		Findings:     findings,
+	}
+
+	var sb strings.Builder
+	if err := htmlReportTemplate.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filename, []byte(sb.String()), 0644); err != nil {
+		return "", err
+	}
+
+	return filename, nil
+}
+
+func newHTMLFinding(index int, f analyzer.Finding) htmlFinding {
+	severity := f.Severity
+	if isCriticalType(f.Type) && f.Severity == "HIGH" {
+		severity = "CRITICAL"
+	}
+	if isPII(f) {
+		severity = "PII"
+	}
+
+	lines := fmt.Sprintf("Line: %d", f.LineNumber)
+	if f.MultiLine() {
+		lines = fmt.Sprintf("Lines: %d-%d", f.LineNumber, f.EndLine)
+	}
+
+	hf := htmlFinding{
+		Index:      index,
+		Severity:   severity,
+		Type:       f.Type,
+		FilePath:   f.FilePath,
+		Lines:      lines,
+		Match:      truncate(f.Match, 4),
+		Confidence: f.Confidence,
+		Also:       strings.Join(f.SecondaryMatches, ", "),
+	}
+	for _, key := range detailKeys(f.Details) {
+		hf.Details = append(hf.Details, [2]string{key, f.Details[key]})
+	}
+	if len(f.Context) > 0 {
+		for i, line := range formatContext(f.Context) {
+			hf.Context = append(hf.Context, htmlContextLine{Text: line, InSpan: f.Context[i].InSpan})
+		}
+	}
+	return hf
+}
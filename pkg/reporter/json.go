@@ -0,0 +1,42 @@
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SaveJSONReport saves the full scan result as JSON to outputDir. Matches
+// are written in full, so the report must be kept private.
+func SaveJSONReport(result ScanResult, outputDir string) (string, error) {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
+
+	timestamp := time.Now().Format("2006-01-02_15-04-05")
+	filename := filepath.Join(outputDir, fmt.Sprintf("kyubisweep_%s.json", timestamp))
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filename, data, 0644); err != nil {
+		return "", err
+	}
+	return filename, nil
+}
+
+// LoadJSONReport reads a report written by SaveJSONReport.
+func LoadJSONReport(path string) (ScanResult, error) {
+	var result ScanResult
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("%s is not a KyubiSweep JSON report: %w", path, err)
+	}
+	return result, nil
+}
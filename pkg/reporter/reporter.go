@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
@@ -36,47 +37,113 @@ type ScanResult struct {
 	EndTime      time.Time
 	FilesScanned int
 	Findings     []analyzer.Finding
+
+	// Incomplete marks a scan that was interrupted. FilesSkipped counts the
+	// files that were queued but never analyzed; how many files the walker
+	// had not reached is unknown.
+	Incomplete   bool
+	FilesSkipped int
+
+	// FilesCached counts the scanned files whose findings were reused
+	// from the cache because they had not changed.
+	FilesCached int
+
+	// FilesFailed counts the files that could not be read or analyzed.
+	// They are not part of FilesScanned.
+	FilesFailed int
+
+	// FindingsBaselined counts the findings left out of Findings because
+	// they are recorded in the baseline.
+	FindingsBaselined int
 }
 
 // PrintScorecard prints the Security Hygiene Scorecard to the terminal
 func PrintScorecard(result ScanResult) {
 	// Count findings by severity
-	criticalCount := 0
-	highCount := 0
-	mediumCount := 0
-	lowCount := 0
+	criticalCount, highCount, mediumCount, lowCount := countBySeverity(result.Findings)
+
+	printHeader()
+	printSummaryBanner(result, criticalCount, highCount, mediumCount, lowCount)
+
+	if piiCounts := countPIIByType(result.Findings); len(piiCounts) > 0 {
+		printPIISummary(piiCounts)
+	}
+
+	if len(result.Findings) > 0 {
+		printFindingsTable(result.Findings)
+	}
 
-	for _, f := range result.Findings {
+	printFooter(result)
+}
+
+// countBySeverity tallies secret findings into the scorecard buckets.
+// Personal data findings are counted separately by countPIIByType.
+func countBySeverity(findings []analyzer.Finding) (critical, high, medium, low int) {
+	for _, f := range findings {
+		if isPII(f) {
+			continue
+		}
 		switch f.Severity {
 		case "HIGH":
 			if isCriticalType(f.Type) {
-				criticalCount++
+				critical++
 			} else {
-				highCount++
+				high++
 			}
 		case "MEDIUM":
-			mediumCount++
+			medium++
 		default:
-			lowCount++
+			low++
 		}
 	}
+	return critical, high, medium, low
+}
 
-	printHeader()
-	printSummaryBanner(result, criticalCount, highCount, mediumCount, lowCount)
+// countPIIByType tallies personal data findings by detector name.
+func countPIIByType(findings []analyzer.Finding) map[string]int {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		if isPII(f) {
+			counts[f.Type]++
+		}
+	}
+	return counts
+}
 
-	if len(result.Findings) > 0 {
-		printFindingsTable(result.Findings)
+func isPII(f analyzer.Finding) bool {
+	return f.Category == analyzer.CategoryPII
+}
+
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	printFooter(result)
+func printPIISummary(counts map[string]int) {
+	fmt.Println(common.Bold("  🪪 PERSONAL DATA (PII)"))
+	fmt.Println(common.Colorize("  ─────────────────────────────────────────", common.ColorDim))
+	for _, name := range sortedKeys(counts) {
+		fmt.Printf("  %s %s\n",
+			common.Colorize(fmt.Sprintf("%3d", counts[name]), common.ColorMagenta),
+			name)
+	}
+	fmt.Println()
 }
 
 func isCriticalType(findingType string) bool {
 	criticalTypes := []string{
-		"AWS Access Key", "AWS Secret Access Key",
+		"AWS Access Key", "AWS Secret Access Key", "AWS Credential Pair",
 		"RSA Private Key", "SSH Private Key", "PGP Private Key",
+		"EC Private Key", "DSA Private Key", "PKCS#8 Private Key",
 		"GitHub Personal Access Token",
 		"PostgreSQL Connection String", "MongoDB Connection String", "MySQL Connection String",
+		"MSSQL Connection String",
+		"Database Credential",
+		"BIP39 Seed Phrase", "Bitcoin WIF Private Key", "Ethereum Private Key",
 	}
 	for _, t := range criticalTypes {
 		if strings.Contains(findingType, t) {
@@ -111,12 +178,18 @@ func printSummaryBanner(result ScanResult, critical, high, medium, low int) {
 	} else if medium > 0 {
 		overallStatus = "⚡ MODERATE ISSUES FOUND"
 		statusColor = common.ColorYellow
+	} else if result.Incomplete {
+		overallStatus = "⏸️  NO SECRETS FOUND BEFORE THE SCAN WAS INTERRUPTED"
+		statusColor = common.ColorYellow
 	} else {
 		overallStatus = "✅ ALL CLEAR - NO SECRETS DETECTED"
 		statusColor = common.ColorGreen
 	}
 
 	fmt.Println(common.Colorize("  "+overallStatus, statusColor))
+	if result.Incomplete {
+		fmt.Println(common.Colorize("  ⚠️  SCAN INCOMPLETE - interrupted before every file was analyzed", common.ColorYellow+common.ColorBold))
+	}
 	fmt.Println()
 
 	fmt.Println(common.Bold("  📊 RISK BREAKDOWN"))
@@ -180,7 +253,10 @@ func printFindingsTable(findings []analyzer.Finding) {
 
 		riskLabel := getRiskLabel(f)
 		typeStr := truncate(f.Type, 30)
-		locationStr := formatLocation(f.FilePath, f.LineNumber)
+		if f.Verification == "verified-active" {
+			typeStr = truncate(f.Type, 24) + " [LIVE]"
+		}
+		locationStr := formatLocation(f.FilePath, f.LineNumber, f.EndLine)
 
 		fmt.Fprintf(w, "  %s\t%s\t%s\n", riskLabel, typeStr, locationStr)
 		displayCount++
@@ -195,7 +271,16 @@ func printFindingsTable(findings []analyzer.Finding) {
 	fmt.Println()
 }
 
+// FindingLine formats a finding as one terminal line: risk label, type and
+// location.
+func FindingLine(f analyzer.Finding) string {
+	return fmt.Sprintf("%s  %s  %s", getRiskLabel(f), f.Type, formatLocation(f.FilePath, f.LineNumber, f.EndLine))
+}
+
 func getRiskLabel(f analyzer.Finding) string {
+	if isPII(f) {
+		return common.Colorize("[PII]     ", common.ColorMagenta)
+	}
 	switch f.Severity {
 	case "HIGH":
 		if isCriticalType(f.Type) {
@@ -209,7 +294,7 @@ func getRiskLabel(f analyzer.Finding) string {
 	}
 }
 
-func formatLocation(filePath string, lineNumber int) string {
+func formatLocation(filePath string, lineNumber, endLine int) string {
 	shortPath := filePath
 	home, _ := os.UserHomeDir()
 	if home != "" {
@@ -221,9 +306,55 @@ func formatLocation(filePath string, lineNumber int) string {
 		shortPath = "..." + shortPath[len(shortPath)-maxLen+3:]
 	}
 
+	if endLine > lineNumber {
+		return fmt.Sprintf("%s:%d-%d", shortPath, lineNumber, endLine)
+	}
 	return fmt.Sprintf("%s:%d", shortPath, lineNumber)
 }
 
+// formatContext renders context lines with line numbers, marking the lines
+// that belong to the finding with ">".
+func formatContext(context []analyzer.ContextLine) []string {
+	width := len(fmt.Sprintf("%d", context[len(context)-1].LineNumber))
+
+	out := make([]string, 0, len(context))
+	for _, line := range context {
+		marker := " "
+		if line.InSpan {
+			marker = ">"
+		}
+		out = append(out, fmt.Sprintf("%s %*d | %s", marker, width, line.LineNumber, line.Text))
+	}
+	return out
+}
+
+// PrintFindingContexts prints the redacted source context captured for each
+// finding, for verbose terminal output.
+func PrintFindingContexts(findings []analyzer.Finding) {
+	printed := 0
+	for _, f := range findings {
+		if len(f.Context) == 0 {
+			continue
+		}
+		if printed == 0 {
+			fmt.Println(common.Bold("  📄 FINDING CONTEXT"))
+			fmt.Println(common.Colorize("  ─────────────────────────────────────────────────────────────────────────", common.ColorDim))
+			fmt.Println()
+		}
+		printed++
+
+		fmt.Printf("  %s %s  %s\n", getRiskLabel(f), common.Bold(f.Type), common.Colorize(formatLocation(f.FilePath, f.LineNumber, f.EndLine), common.ColorDim))
+		for i, line := range formatContext(f.Context) {
+			if f.Context[i].InSpan {
+				fmt.Printf("    %s\n", common.Yellow(line))
+			} else {
+				fmt.Printf("    %s\n", common.Colorize(line, common.ColorDim))
+			}
+		}
+		fmt.Println()
+	}
+}
+
 func truncate(s string, maxLen int) string {
 	if len(s) > maxLen {
 		return s[:maxLen-3] + "..."
@@ -244,7 +375,22 @@ func printFooter(result ScanResult) {
 
 	fmt.Printf("  📁 Scanned: %s\n", common.Bold(scanPath))
 	fmt.Printf("  📄 Files analyzed: %s\n", common.Bold(formatNumber(result.FilesScanned)))
+	if result.FilesCached > 0 {
+		fmt.Printf("  ♻️  Unchanged (from cache): %s\n", common.Bold(formatNumber(result.FilesCached)))
+	}
+	if result.FilesFailed > 0 {
+		fmt.Printf("  ❌ Could not analyze: %s\n", common.Colorize(formatNumber(result.FilesFailed), common.ColorYellow))
+	}
+	if result.Incomplete {
+		fmt.Printf("  ⏭️  Files skipped: %s (files not yet reached: unknown)\n", common.Colorize(formatNumber(result.FilesSkipped), common.ColorYellow))
+	}
+	if result.FindingsBaselined > 0 {
+		fmt.Printf("  📌 Known (in baseline): %s\n", common.Bold(formatNumber(result.FindingsBaselined)))
+	}
 	fmt.Printf("  ⏱️  Duration: %s\n", common.Bold(formatDuration(duration)))
+	if active, checked := countVerified(result.Findings); checked > 0 {
+		fmt.Printf("  🔑 Verified live: %s of %d checked\n", common.Colorize(fmt.Sprintf("%d", active), common.ColorRed+common.ColorBold), checked)
+	}
 	fmt.Printf("  🕐 Timestamp: %s\n", time.Now().Format("2006-01-02 15:04:05"))
 	fmt.Println()
 
@@ -252,6 +398,29 @@ func printFooter(result ScanResult) {
 	fmt.Println()
 }
 
+// detailKeys returns the keys of Details in sorted order.
+func detailKeys(details map[string]string) []string {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func countVerified(findings []analyzer.Finding) (active int, checked int) {
+	for _, f := range findings {
+		if f.Verification == "" {
+			continue
+		}
+		checked++
+		if f.Verification == "verified-active" {
+			active++
+		}
+	}
+	return active, checked
+}
+
 func formatNumber(n int) string {
 	if n >= 1000000 {
 		return fmt.Sprintf("%.1fM", float64(n)/1000000)
@@ -286,23 +455,18 @@ func SaveMarkdownReport(result ScanResult, outputDir string) (string, error) {
 	sb.WriteString(fmt.Sprintf("**Scan Time:** %s\n\n", timestamp))
 	sb.WriteString(fmt.Sprintf("**Target:** `%s`\n\n", result.ScanPath))
 	sb.WriteString(fmt.Sprintf("**Files Scanned:** %d\n\n", result.FilesScanned))
+	if result.FilesFailed > 0 {
+		sb.WriteString(fmt.Sprintf("**Could Not Analyze:** %d\n\n", result.FilesFailed))
+	}
+	if result.FindingsBaselined > 0 {
+		sb.WriteString(fmt.Sprintf("**Known (in baseline):** %d\n\n", result.FindingsBaselined))
+	}
+	if result.Incomplete {
+		sb.WriteString(fmt.Sprintf("> ⚠️ **Incomplete scan:** interrupted before every file was analyzed. %d queued files were skipped; the number of files not yet reached is unknown.\n\n", result.FilesSkipped))
+	}
 	sb.WriteString(fmt.Sprintf("**Duration:** %s\n\n", formatDuration(result.EndTime.Sub(result.StartTime))))
 
-	critical, high, medium, low := 0, 0, 0, 0
-	for _, f := range result.Findings {
-		switch f.Severity {
-		case "HIGH":
-			if isCriticalType(f.Type) {
-				critical++
-			} else {
-				high++
-			}
-		case "MEDIUM":
-			medium++
-		default:
-			low++
-		}
-	}
+	critical, high, medium, low := countBySeverity(result.Findings)
 
 	sb.WriteString("## Summary\n\n")
 	sb.WriteString("| Severity | Count |\n")
@@ -311,7 +475,17 @@ func SaveMarkdownReport(result ScanResult, outputDir string) (string, error) {
 	sb.WriteString(fmt.Sprintf("| 🔴 HIGH | %d |\n", high))
 	sb.WriteString(fmt.Sprintf("| 🟡 MEDIUM | %d |\n", medium))
 	sb.WriteString(fmt.Sprintf("| 🔵 LOW | %d |\n", low))
-	sb.WriteString(fmt.Sprintf("| **Total** | **%d** |\n\n", len(result.Findings)))
+	sb.WriteString(fmt.Sprintf("| **Total** | **%d** |\n\n", critical+high+medium+low))
+
+	if piiCounts := countPIIByType(result.Findings); len(piiCounts) > 0 {
+		sb.WriteString("## Personal Data (PII)\n\n")
+		sb.WriteString("| Type | Count |\n")
+		sb.WriteString("|------|-------|\n")
+		for _, name := range sortedKeys(piiCounts) {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", name, piiCounts[name]))
+		}
+		sb.WriteString("\n")
+	}
 
 	if len(result.Findings) > 0 {
 		sb.WriteString("## Findings\n\n")
@@ -320,10 +494,40 @@ func SaveMarkdownReport(result ScanResult, outputDir string) (string, error) {
 			if isCriticalType(f.Type) && f.Severity == "HIGH" {
 				severity = "CRITICAL"
 			}
+			if isPII(f) {
+				severity = "PII"
+			}
 			sb.WriteString(fmt.Sprintf("### %d. [%s] %s\n\n", i+1, severity, f.Type))
 			sb.WriteString(fmt.Sprintf("- **File:** `%s`\n", f.FilePath))
-			sb.WriteString(fmt.Sprintf("- **Line:** %d\n", f.LineNumber))
-			sb.WriteString(fmt.Sprintf("- **Match:** `%s`\n\n", truncate(f.Match, 4)))
+			if f.MultiLine() {
+				sb.WriteString(fmt.Sprintf("- **Lines:** %d-%d\n", f.LineNumber, f.EndLine))
+			} else {
+				sb.WriteString(fmt.Sprintf("- **Line:** %d\n", f.LineNumber))
+			}
+			sb.WriteString(fmt.Sprintf("- **Match:** `%s`\n", truncate(f.Match, 4)))
+			if f.Confidence != "" {
+				sb.WriteString(fmt.Sprintf("- **Confidence:** %s\n", f.Confidence))
+			}
+			if f.Verification != "" {
+				sb.WriteString(fmt.Sprintf("- **Verification:** %s\n", f.Verification))
+			}
+			for _, key := range detailKeys(f.Details) {
+				sb.WriteString(fmt.Sprintf("- **%s:** %s\n", key, f.Details[key]))
+			}
+			for _, rel := range f.Related {
+				sb.WriteString(fmt.Sprintf("- **%s:** line %d\n", rel.Type, rel.LineNumber))
+			}
+			if len(f.SecondaryMatches) > 0 {
+				sb.WriteString(fmt.Sprintf("- **Also Matched:** %s\n", strings.Join(f.SecondaryMatches, ", ")))
+			}
+			if len(f.Context) > 0 {
+				sb.WriteString("\n```\n")
+				for _, line := range formatContext(f.Context) {
+					sb.WriteString(line + "\n")
+				}
+				sb.WriteString("```\n")
+			}
+			sb.WriteString("\n")
 		}
 	}
 
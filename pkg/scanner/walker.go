@@ -2,10 +2,14 @@
 package scanner
 
 import (
+	"context"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
 )
 
 // Directories to skip during scanning
@@ -69,69 +73,189 @@ var DefaultTextExtensions = map[string]bool{
 	".gradle": true, ".plist": true, ".xcconfig": true,
 }
 
-// maxFileSize is the maximum file size to scan (5 MB - reduced for text files)
-const maxFileSize = 5 * 1024 * 1024
+// maxFileSize is the maximum file size to scan, the most the analyzer reads
+const maxFileSize = analyzer.MaxFileSize
 
 // Walk traverses the directory tree and sends file paths to the channel.
 // It uses the allowedExtensions map to filter files. Pass nil to use defaults.
 func Walk(rootPath string, filePaths chan<- string, verbose bool, allowedExtensions map[string]bool) {
+	_ = WalkContext(context.Background(), rootPath, filePaths, verbose, allowedExtensions)
+}
+
+// WalkContext is like Walk but stops as soon as ctx is cancelled, returning
+// ctx's error. Files not yet reached are never sent.
+func WalkContext(ctx context.Context, rootPath string, filePaths chan<- string, verbose bool, allowedExtensions map[string]bool) error {
 	// Use default extensions if none provided
 	if allowedExtensions == nil {
 		allowedExtensions = DefaultTextExtensions
 	}
 
-	_ = filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+	return filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			if os.IsPermission(err) {
-				return nil
-			}
+			// Unreadable entries (e.g. permission denied) are skipped
 			return nil
 		}
 
 		// Skip unwanted directories
 		if d.IsDir() {
-			dirName := d.Name()
-			if skipDirs[dirName] {
-				return fs.SkipDir
-			}
-			if strings.HasPrefix(dirName, ".") && dirName != "." {
+			if IgnoredDir(d.Name()) {
 				return fs.SkipDir
 			}
 			return nil
 		}
 
-		// Filter out unwanted files
-		fileName := d.Name()
-		if strings.HasPrefix(fileName, ".") && fileName != ".env" && !strings.HasPrefix(fileName, ".env.") {
-			// Skip hidden files except .env files
-			if !allowedExtensions[fileName] {
-				return nil
+		if !WantFile(d, allowedExtensions) {
+			return nil
+		}
+
+		// Send file path to channel for processing
+		select {
+		case filePaths <- path:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// WalkParallel is like WalkContext but reads up to workers directories at
+// once, fanning out over subdirectories. Paths are sent in no particular
+// order. workers <= 1 walks sequentially.
+func WalkParallel(ctx context.Context, rootPath string, filePaths chan<- string, workers int, allowedExtensions map[string]bool) error {
+	if workers <= 1 {
+		return WalkContext(ctx, rootPath, filePaths, false, allowedExtensions)
+	}
+	if allowedExtensions == nil {
+		allowedExtensions = DefaultTextExtensions
+	}
+
+	info, err := os.Lstat(rootPath)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		if WantFile(fs.FileInfoToDirEntry(info), allowedExtensions) {
+			select {
+			case filePaths <- rootPath:
+			case <-ctx.Done():
 			}
 		}
+		return ctx.Err()
+	}
+	if IgnoredDir(info.Name()) {
+		return nil
+	}
+
+	w := &parallelWalker{
+		ctx:        ctx,
+		filePaths:  filePaths,
+		extensions: allowedExtensions,
+		slots:      make(chan struct{}, workers-1),
+	}
+	w.wg.Add(1)
+	w.walkDir(rootPath)
+	w.wg.Wait()
+
+	return ctx.Err()
+}
+
+// parallelWalker holds the state shared by the goroutines of WalkParallel.
+type parallelWalker struct {
+	ctx        context.Context
+	filePaths  chan<- string
+	extensions map[string]bool
+	slots      chan struct{} // one token per extra goroutine allowed
+	wg         sync.WaitGroup
+}
 
-		// Check extension - only scan allowed text-based files
-		ext := strings.ToLower(filepath.Ext(fileName))
-		if ext == "" {
-			// Check if filename itself is in allowed list (e.g., "Dockerfile", ".gitignore")
-			if !allowedExtensions[strings.ToLower(fileName)] {
-				return nil
+// walkDir sends the wanted files in dir and walks its subdirectories,
+// handing each to a new goroutine when a slot is free and walking it
+// inline otherwise.
+func (w *parallelWalker) walkDir(dir string) {
+	defer w.wg.Done()
+	if w.ctx.Err() != nil {
+		return
+	}
+
+	// ReadDir returns the entries it could read even on error
+	entries, _ := os.ReadDir(dir)
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+
+		if entry.IsDir() {
+			if IgnoredDir(entry.Name()) {
+				continue
 			}
-		} else if !allowedExtensions[ext] {
-			return nil
+			w.wg.Add(1)
+			select {
+			case w.slots <- struct{}{}:
+				go func() {
+					w.walkDir(path)
+					<-w.slots
+				}()
+			default:
+				w.walkDir(path)
+			}
+			continue
 		}
 
-		info, err := d.Info()
-		if err != nil {
-			return nil
+		if !WantFile(entry, w.extensions) {
+			continue
 		}
-		if info.Size() > maxFileSize || info.Size() == 0 {
-			return nil
+		select {
+		case w.filePaths <- path:
+		case <-w.ctx.Done():
+			return
 		}
+	}
+}
 
-		// Send file path to channel for processing
-		filePaths <- path
-		return nil
-	})
+// IgnoredDir reports whether the walker skips directories named dirName:
+// VCS, dependency and build directories, and hidden directories.
+func IgnoredDir(dirName string) bool {
+	if skipDirs[dirName] {
+		return true
+	}
+	return strings.HasPrefix(dirName, ".") && dirName != "."
+}
+
+// WantFile reports whether the walker would scan the file d: it must have
+// an allowed extension (nil means DefaultTextExtensions), must not be a
+// hidden file other than .env files, and must be non-empty and no larger
+// than the scan size limit.
+func WantFile(d fs.DirEntry, allowedExtensions map[string]bool) bool {
+	if allowedExtensions == nil {
+		allowedExtensions = DefaultTextExtensions
+	}
+
+	// Filter out unwanted files
+	fileName := d.Name()
+	if strings.HasPrefix(fileName, ".") && fileName != ".env" && !strings.HasPrefix(fileName, ".env.") {
+		// Skip hidden files except .env files
+		if !allowedExtensions[fileName] {
+			return false
+		}
+	}
+
+	// Check extension - only scan allowed text-based files
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if ext == "" {
+		// Check if filename itself is in allowed list (e.g., "Dockerfile", ".gitignore")
+		if !allowedExtensions[strings.ToLower(fileName)] {
+			return false
+		}
+	} else if !allowedExtensions[ext] {
+		return false
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		return false
+	}
+	return info.Size() <= maxFileSize && info.Size() != 0
 }
 
 // MergeExtensions combines default extensions with additional ones
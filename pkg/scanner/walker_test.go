@@ -1,8 +1,14 @@
 package scanner
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 	"testing"
 )
 
@@ -226,6 +232,132 @@ func TestWalkEmptyDirectory(t *testing.T) {
 	}
 }
 
+func TestWalkContextStopsWhenCancelled(t *testing.T) {
+	rootDir := t.TempDir()
+	for i := 0; i < 20; i++ {
+		path := filepath.Join(rootDir, fmt.Sprintf("file%02d.txt", i))
+		if err := os.WriteFile(path, []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Unbuffered: the walker blocks on every send until it is received
+	filePaths := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		done <- WalkContext(ctx, rootDir, filePaths, false, DefaultTextExtensions)
+		close(filePaths)
+	}()
+
+	<-filePaths
+	cancel()
+
+	received := 1
+	for range filePaths {
+		received++
+	}
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if received >= 20 {
+		t.Errorf("Expected the walk to stop early, received all %d files", received)
+	}
+}
+
+func TestWalkParallelMatchesWalk(t *testing.T) {
+	rootDir := t.TempDir()
+	files := []string{
+		"a.go", "b.exe", ".env", ".hidden.txt", "Dockerfile",
+		"src/app/main.go", "src/app/config.json", "src/lib/util.py",
+		"src/lib/deep/er/still.yaml", "node_modules/pkg/index.js",
+		".git/config", "vendor/x/y.go", "docs/empty.md",
+	}
+	for _, f := range files {
+		path := filepath.Join(rootDir, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", f, err)
+		}
+		content := "content"
+		if f == "docs/empty.md" {
+			content = ""
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to create file %s: %v", path, err)
+		}
+	}
+
+	collect := func(walk func(chan<- string)) []string {
+		filePaths := make(chan string, 100)
+		go func() {
+			walk(filePaths)
+			close(filePaths)
+		}()
+		var found []string
+		for path := range filePaths {
+			found = append(found, path)
+		}
+		sort.Strings(found)
+		return found
+	}
+
+	want := collect(func(ch chan<- string) { Walk(rootDir, ch, false, nil) })
+	for _, workers := range []int{1, 2, 8} {
+		got := collect(func(ch chan<- string) {
+			if err := WalkParallel(context.Background(), rootDir, ch, workers, nil); err != nil {
+				t.Errorf("WalkParallel failed: %v", err)
+			}
+		})
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("workers=%d: WalkParallel found %v, Walk found %v", workers, got, want)
+		}
+	}
+	if len(want) != 6 {
+		t.Errorf("Expected 6 files, found %d: %v", len(want), want)
+	}
+}
+
+func TestWalkParallelStopsWhenCancelled(t *testing.T) {
+	rootDir := t.TempDir()
+	for i := 0; i < 20; i++ {
+		dir := filepath.Join(rootDir, fmt.Sprintf("dir%02d", i))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte("content"), 0644); err != nil {
+			t.Fatalf("Failed to create file: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	filePaths := make(chan string)
+	done := make(chan error, 1)
+	go func() {
+		done <- WalkParallel(ctx, rootDir, filePaths, 4, DefaultTextExtensions)
+		close(filePaths)
+	}()
+
+	<-filePaths
+	cancel()
+
+	received := 1
+	for range filePaths {
+		received++
+	}
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if received >= 20 {
+		t.Errorf("Expected the walk to stop early, received all %d files", received)
+	}
+}
+
 func TestMergeExtensions(t *testing.T) {
 	// Test merging custom extensions
 	merged := MergeExtensions([]string{"dat", "log", "custom"})
@@ -264,3 +396,38 @@ func TestMergeExtensionsNormalization(t *testing.T) {
 		t.Error("Should normalize 'JSON' to '.json' (lowercase)")
 	}
 }
+
+// =============================================================================
+// BENCHMARK: Directory Walking
+// Walks a wide generated tree with an increasing number of workers
+// =============================================================================
+
+func BenchmarkWalkParallel(b *testing.B) {
+	rootDir := b.TempDir()
+	for i := 0; i < 200; i++ {
+		dir := filepath.Join(rootDir, fmt.Sprintf("pkg%03d", i), "internal")
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+		for j := 0; j < 25; j++ {
+			name := filepath.Join(dir, fmt.Sprintf("file%02d.go", j))
+			if err := os.WriteFile(name, []byte("package internal\n"), 0644); err != nil {
+				b.Fatalf("Failed to create file %s: %v", name, err)
+			}
+		}
+	}
+
+	for workers := 1; workers <= max(8, runtime.NumCPU()); workers *= 2 {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				filePaths := make(chan string, 100)
+				go func() {
+					_ = WalkParallel(context.Background(), rootDir, filePaths, workers, nil)
+					close(filePaths)
+				}()
+				for range filePaths {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package verify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+const (
+	awsRegion  = "us-east-1"
+	awsService = "sts"
+	stsBody    = "Action=GetCallerIdentity&Version=2011-06-15"
+)
+
+// AWSVerifier checks an access key pair with STS GetCallerIdentity, which
+// succeeds for any valid key regardless of its IAM permissions.
+type AWSVerifier struct {
+	BaseURL string
+	Client  *http.Client
+
+	// now is overridable for signature tests.
+	now func() time.Time
+}
+
+// Verify signs a GetCallerIdentity request with the finding's key pair.
+// A lone access key ID or secret cannot be checked and yields StatusUnknown.
+func (v *AWSVerifier) Verify(ctx context.Context, f analyzer.Finding) (Status, error) {
+	keyID, secret, ok := awsCredentials(f)
+	if !ok {
+		return StatusUnknown, nil
+	}
+	return v.VerifyKeyPair(ctx, keyID, secret)
+}
+
+// VerifyKeyPair checks an explicit access key ID and secret access key.
+func (v *AWSVerifier) VerifyKeyPair(ctx context.Context, keyID, secret string) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(v.BaseURL, "/")+"/", strings.NewReader(stsBody))
+	if err != nil {
+		return StatusUnknown, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	now := time.Now
+	if v.now != nil {
+		now = v.now
+	}
+	signAWSRequest(req, keyID, secret, now().UTC())
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return StatusActive, nil
+	case resp.StatusCode == http.StatusForbidden && (strings.Contains(string(body), "InvalidClientTokenId") ||
+		strings.Contains(string(body), "SignatureDoesNotMatch")):
+		return StatusInactive, nil
+	default:
+		return StatusUnknown, fmt.Errorf("unexpected status %d from STS", resp.StatusCode)
+	}
+}
+
+// awsCredentials extracts the access key ID and secret from the components
+// of a composite "AWS Credential Pair" finding. Single-value findings lack
+// the other half.
+func awsCredentials(f analyzer.Finding) (keyID, secret string, ok bool) {
+	for _, rel := range f.Related {
+		switch rel.Type {
+		case "AWS Access Key ID":
+			keyID = rel.Match
+		case "AWS Secret Access Key":
+			secret = rel.Match
+		}
+	}
+	return keyID, secret, keyID != "" && secret != ""
+}
+
+// signAWSRequest adds AWS Signature Version 4 headers for an STS request.
+func signAWSRequest(req *http.Request, keyID, secret string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	dateStamp := t.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	host := req.URL.Host
+
+	payloadHash := sha256Hex(stsBody)
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + host + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	signedHeaders := "content-type;host;x-amz-date"
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"", // no query string
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := dateStamp + "/" + awsRegion + "/" + awsService + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex(canonicalRequest),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secret), dateStamp)
+	signingKey = hmacSHA256(signingKey, awsRegion)
+	signingKey = hmacSHA256(signingKey, awsService)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		keyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
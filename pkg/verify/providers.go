@@ -0,0 +1,72 @@
+package verify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// GitHubVerifier checks GitHub tokens against the authenticated user endpoint.
+type GitHubVerifier struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Verify calls GET /user with the token.
+func (v *GitHubVerifier) Verify(ctx context.Context, f analyzer.Finding) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(v.BaseURL, "/")+"/user", nil)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	req.Header.Set("Authorization", "token "+f.Match)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	return doRequest(v.Client, req)
+}
+
+// StripeVerifier checks Stripe secret keys against the balance endpoint.
+type StripeVerifier struct {
+	BaseURL string
+	Client  *http.Client
+}
+
+// Verify calls GET /v1/balance with the key as the basic-auth user.
+// Restricted keys without balance access answer 403 but are still live.
+func (v *StripeVerifier) Verify(ctx context.Context, f analyzer.Finding) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(v.BaseURL, "/")+"/v1/balance", nil)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	req.SetBasicAuth(f.Match, "")
+
+	resp, err := v.Client.Do(req)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode == http.StatusForbidden {
+		return StatusActive, nil
+	}
+	return statusFromHTTP(resp.StatusCode), nil
+}
+
+func doRequest(client *http.Client, req *http.Request) (Status, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return StatusUnknown, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	status := statusFromHTTP(resp.StatusCode)
+	if status == StatusUnknown {
+		return status, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+	}
+	return status, nil
+}
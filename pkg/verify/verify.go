@@ -0,0 +1,181 @@
+// Package verify checks whether discovered credentials are still live by
+// calling the issuing provider's API. Verification sends the secret over the
+// network, so it only runs when explicitly requested.
+package verify
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// Status is the outcome of verifying a single finding.
+type Status string
+
+const (
+	StatusActive   Status = "verified-active"
+	StatusInactive Status = "inactive"
+	StatusUnknown  Status = "unknown"
+)
+
+// Default provider endpoints. Override them in Config to point at a mock server.
+const (
+	DefaultGitHubBaseURL = "https://api.github.com"
+	DefaultStripeBaseURL = "https://api.stripe.com"
+	DefaultAWSSTSBaseURL = "https://sts.amazonaws.com"
+)
+
+// Verifier tests a candidate credential against its provider.
+// Implementations return StatusUnknown with a nil error when the finding does
+// not carry enough information to be checked.
+type Verifier interface {
+	Verify(ctx context.Context, f analyzer.Finding) (Status, error)
+}
+
+// Config holds the endpoints and HTTP settings shared by all verifiers.
+type Config struct {
+	GitHubBaseURL string
+	StripeBaseURL string
+	AWSSTSBaseURL string
+
+	// Timeout bounds each individual verification request.
+	Timeout time.Duration
+
+	// Concurrency is the number of verifications run in parallel.
+	Concurrency int
+
+	// Client is used for all requests. Defaults to a client with Timeout.
+	Client *http.Client
+}
+
+// DefaultConfig returns a Config pointing at the real provider APIs.
+func DefaultConfig() Config {
+	return Config{
+		GitHubBaseURL: DefaultGitHubBaseURL,
+		StripeBaseURL: DefaultStripeBaseURL,
+		AWSSTSBaseURL: DefaultAWSSTSBaseURL,
+		Timeout:       10 * time.Second,
+		Concurrency:   4,
+	}
+}
+
+// Registry maps rule names to the verifier responsible for them.
+type Registry struct {
+	verifiers   map[string]Verifier
+	timeout     time.Duration
+	concurrency int
+}
+
+// NewRegistry builds a registry with the built-in verifiers configured from cfg.
+// Empty fields in cfg fall back to DefaultConfig.
+func NewRegistry(cfg Config) *Registry {
+	defaults := DefaultConfig()
+	if cfg.GitHubBaseURL == "" {
+		cfg.GitHubBaseURL = defaults.GitHubBaseURL
+	}
+	if cfg.StripeBaseURL == "" {
+		cfg.StripeBaseURL = defaults.StripeBaseURL
+	}
+	if cfg.AWSSTSBaseURL == "" {
+		cfg.AWSSTSBaseURL = defaults.AWSSTSBaseURL
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = defaults.Concurrency
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+
+	github := &GitHubVerifier{BaseURL: cfg.GitHubBaseURL, Client: cfg.Client}
+	stripe := &StripeVerifier{BaseURL: cfg.StripeBaseURL, Client: cfg.Client}
+	aws := &AWSVerifier{BaseURL: cfg.AWSSTSBaseURL, Client: cfg.Client}
+
+	r := &Registry{
+		verifiers:   make(map[string]Verifier),
+		timeout:     cfg.Timeout,
+		concurrency: cfg.Concurrency,
+	}
+	r.Register("GitHub Personal Access Token", github)
+	r.Register("GitHub OAuth Access Token", github)
+	r.Register("GitHub App Token", github)
+	r.Register("Stripe Secret Key", stripe)
+	r.Register("AWS Access Key ID", aws)
+	r.Register("AWS Secret Access Key", aws)
+	r.Register("AWS Credential Pair", aws)
+
+	return r
+}
+
+// Register associates a verifier with a rule name, replacing any existing one.
+func (r *Registry) Register(ruleName string, v Verifier) {
+	r.verifiers[ruleName] = v
+}
+
+// Lookup returns the verifier for a rule name, if any.
+func (r *Registry) Lookup(ruleName string) (Verifier, bool) {
+	v, ok := r.verifiers[ruleName]
+	return v, ok
+}
+
+// VerifyAll verifies every finding that has a registered verifier and records
+// the result in its Verification field. Findings without a verifier are left
+// untouched. Low-confidence findings (e.g. failed checksums) are not sent to
+// providers and are marked unknown.
+func (r *Registry) VerifyAll(ctx context.Context, findings []analyzer.Finding) {
+	sem := make(chan struct{}, r.concurrency)
+	var wg sync.WaitGroup
+
+	for i := range findings {
+		v, ok := r.verifiers[findings[i].Type]
+		if !ok {
+			continue
+		}
+		if findings[i].Confidence == "LOW" {
+			findings[i].Verification = string(StatusUnknown)
+			continue
+		}
+
+		wg.Add(1)
+		go func(f *analyzer.Finding, v Verifier) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				f.Verification = string(StatusUnknown)
+				return
+			}
+
+			reqCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+
+			status, err := v.Verify(reqCtx, *f)
+			if err != nil {
+				status = StatusUnknown
+			}
+			f.Verification = string(status)
+		}(&findings[i], v)
+	}
+
+	wg.Wait()
+}
+
+// statusFromHTTP maps the common provider responses to a Status:
+// 2xx means the credential works, 401 means it was rejected.
+func statusFromHTTP(code int) Status {
+	switch {
+	case code >= 200 && code < 300:
+		return StatusActive
+	case code == http.StatusUnauthorized:
+		return StatusInactive
+	default:
+		return StatusUnknown
+	}
+}
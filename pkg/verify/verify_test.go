@@ -0,0 +1,149 @@
+package verify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// =============================================================================
+// TEST: Provider Verifiers
+// Uses httptest servers in place of the real provider APIs
+// =============================================================================
+
+func TestGitHubVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" {
+			t.Errorf("Unexpected path %q", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") == "token live-token" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	v := &GitHubVerifier{BaseURL: server.URL, Client: server.Client()}
+
+	tests := []struct {
+		token string
+		want  Status
+	}{
+		{"live-token", StatusActive},
+		{"revoked-token", StatusInactive},
+	}
+
+	for _, tc := range tests {
+		got, err := v.Verify(context.Background(), analyzer.Finding{Match: tc.token})
+		if err != nil {
+			t.Fatalf("Verify(%q) returned error: %v", tc.token, err)
+		}
+		if got != tc.want {
+			t.Errorf("Verify(%q) = %s, want %s", tc.token, got, tc.want)
+		}
+	}
+}
+
+func TestStripeVerifierRestrictedKeyIsActive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "restricted" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	v := &StripeVerifier{BaseURL: server.URL, Client: server.Client()}
+
+	if got, _ := v.Verify(context.Background(), analyzer.Finding{Match: "restricted"}); got != StatusActive {
+		t.Errorf("Restricted key: got %s, want %s", got, StatusActive)
+	}
+	if got, _ := v.Verify(context.Background(), analyzer.Finding{Match: "bogus"}); got != StatusInactive {
+		t.Errorf("Bogus key: got %s, want %s", got, StatusInactive)
+	}
+}
+
+func TestAWSVerifierSignsRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDGOOD/20240102/us-east-1/sts/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte("<Code>InvalidClientTokenId</Code>"))
+			return
+		}
+		if r.Header.Get("X-Amz-Date") != "20240102T030405Z" {
+			t.Errorf("Unexpected X-Amz-Date %q", r.Header.Get("X-Amz-Date"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	fixed := func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+	v := &AWSVerifier{BaseURL: server.URL, Client: server.Client(), now: fixed}
+
+	if got, err := v.VerifyKeyPair(context.Background(), "AKIDGOOD", "secret"); got != StatusActive || err != nil {
+		t.Errorf("Good pair: got %s (%v), want %s", got, err, StatusActive)
+	}
+	if got, err := v.VerifyKeyPair(context.Background(), "AKIDBAD", "secret"); got != StatusInactive || err != nil {
+		t.Errorf("Bad pair: got %s (%v), want %s", got, err, StatusInactive)
+	}
+	if got, _ := v.Verify(context.Background(), analyzer.Finding{Type: "AWS Access Key ID", Match: "AKIDGOOD"}); got != StatusUnknown {
+		t.Errorf("Lone key ID: got %s, want %s", got, StatusUnknown)
+	}
+}
+
+func TestRegistryVerifyAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	registry := NewRegistry(Config{GitHubBaseURL: server.URL, Client: server.Client()})
+
+	findings := []analyzer.Finding{
+		{Type: "GitHub Personal Access Token", Match: "token", Confidence: "HIGH"},
+		{Type: "GitHub Personal Access Token", Match: "lookalike", Confidence: "LOW"},
+		{Type: "High Entropy String", Match: "random"},
+	}
+	registry.VerifyAll(context.Background(), findings)
+
+	if findings[0].Verification != string(StatusActive) {
+		t.Errorf("Expected first finding to be verified active, got %q", findings[0].Verification)
+	}
+	if findings[1].Verification != string(StatusUnknown) {
+		t.Errorf("Expected low-confidence finding to be skipped as unknown, got %q", findings[1].Verification)
+	}
+	if findings[2].Verification != "" {
+		t.Errorf("Expected finding without verifier to stay unchecked, got %q", findings[2].Verification)
+	}
+}
+
+func TestAWSVerifierUsesCredentialPair(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Authorization"), "Credential=AKIDPAIR/") {
+			t.Errorf("Expected the pair's key ID in the signature, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	v := &AWSVerifier{BaseURL: server.URL, Client: server.Client()}
+	pair := analyzer.Finding{
+		Type: "AWS Credential Pair",
+		Related: []analyzer.Location{
+			{Type: "AWS Access Key ID", Match: "AKIDPAIR"},
+			{Type: "AWS Secret Access Key", Match: "secret"},
+		},
+	}
+
+	if got, err := v.Verify(context.Background(), pair); got != StatusActive || err != nil {
+		t.Errorf("Credential pair: got %s (%v), want %s", got, err, StatusActive)
+	}
+}
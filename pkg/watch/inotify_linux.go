@@ -0,0 +1,146 @@
+//go:build linux
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+)
+
+// watchMask selects the events that mean a file's content may have changed,
+// plus directory creation so new subtrees get watched.
+const watchMask = syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_MOVED_TO | syscall.IN_CREATE | syscall.IN_ONLYDIR
+
+// inotifyNotifier watches every non-ignored directory under root.
+type inotifyNotifier struct {
+	root string
+	fd   int
+	file *os.File
+	dirs map[int32]string // watch descriptor to directory
+}
+
+func newInotifyNotifier(root string) (*inotifyNotifier, error) {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &inotifyNotifier{
+		root: root,
+		fd:   fd,
+		file: os.NewFile(uintptr(fd), "inotify"),
+		dirs: make(map[int32]string),
+	}
+	if err := n.addTree(root); err != nil {
+		n.file.Close()
+		return nil, err
+	}
+	return n, nil
+}
+
+// addTree watches dir and every non-ignored directory below it. Running
+// out of watches (ENOSPC) is an error; directories that vanish or cannot
+// be read are skipped.
+func (n *inotifyNotifier) addTree(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		if path != n.root && scanner.IgnoredDir(d.Name()) {
+			return fs.SkipDir
+		}
+
+		wd, err := syscall.InotifyAddWatch(n.fd, path, watchMask)
+		if errors.Is(err, syscall.ENOSPC) {
+			return err
+		}
+		if err == nil {
+			n.dirs[int32(wd)] = path
+		}
+		return nil
+	})
+}
+
+func (n *inotifyNotifier) run(ctx context.Context, changes chan<- string) error {
+	// Closing the file unblocks the pending Read
+	go func() {
+		<-ctx.Done()
+		n.file.Close()
+	}()
+
+	buf := make([]byte, 64*1024)
+	for {
+		count, err := n.file.Read(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+
+		for offset := 0; offset+syscall.SizeofInotifyEvent <= count; {
+			event := (*syscall.InotifyEvent)(unsafe.Pointer(&buf[offset]))
+			nameStart := offset + syscall.SizeofInotifyEvent
+			name := strings.TrimRight(string(buf[nameStart:nameStart+int(event.Len)]), "\x00")
+			offset = nameStart + int(event.Len)
+
+			for _, path := range n.handle(event.Wd, event.Mask, name) {
+				select {
+				case changes <- path:
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}
+		}
+	}
+}
+
+// handle updates the watch list for one event and returns the files it
+// may have changed.
+func (n *inotifyNotifier) handle(wd int32, mask uint32, name string) []string {
+	switch {
+	case mask&syscall.IN_Q_OVERFLOW != 0:
+		// Events were lost; treat every file as changed
+		return n.filesUnder(n.root)
+
+	case mask&syscall.IN_IGNORED != 0:
+		delete(n.dirs, wd)
+		return nil
+	}
+
+	dir, ok := n.dirs[wd]
+	if !ok || name == "" {
+		return nil
+	}
+	path := filepath.Join(dir, name)
+
+	if mask&syscall.IN_ISDIR != 0 {
+		if mask&(syscall.IN_CREATE|syscall.IN_MOVED_TO) == 0 || scanner.IgnoredDir(name) {
+			return nil
+		}
+		// Files may land in a new directory before its watch exists
+		_ = n.addTree(path)
+		return n.filesUnder(path)
+	}
+
+	if mask&(syscall.IN_CLOSE_WRITE|syscall.IN_MODIFY|syscall.IN_MOVED_TO) != 0 {
+		return []string{path}
+	}
+	return nil
+}
+
+func (n *inotifyNotifier) filesUnder(dir string) []string {
+	var paths []string
+	walkFiles(dir, func(path string, d fs.DirEntry) {
+		paths = append(paths, path)
+	})
+	return paths
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package watch
+
+import (
+	"context"
+	"errors"
+)
+
+// inotifyNotifier is unavailable off Linux; New falls back to polling.
+type inotifyNotifier struct{}
+
+func newInotifyNotifier(root string) (*inotifyNotifier, error) {
+	return nil, errors.New("inotify is only available on Linux")
+}
+
+func (n *inotifyNotifier) run(ctx context.Context, changes chan<- string) error {
+	return errors.New("inotify is only available on Linux")
+}
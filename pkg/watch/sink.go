@@ -0,0 +1,72 @@
+package watch
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// Sink receives the findings discovered while watching.
+type Sink interface {
+	Emit(f analyzer.Finding) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(f analyzer.Finding) error
+
+// Emit calls fn(f).
+func (fn SinkFunc) Emit(f analyzer.Finding) error {
+	return fn(f)
+}
+
+// JSONLinesSink writes each finding as one line of JSON.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONLinesSink returns a sink writing JSON lines to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{enc: json.NewEncoder(w)}
+}
+
+// Emit writes f followed by a newline.
+func (s *JSONLinesSink) Emit(f analyzer.Finding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(f)
+}
+
+// Tracker remembers what was last found in each file so that saving a file
+// again only reports secrets that are new to it.
+type Tracker struct {
+	seen map[string]map[string]bool
+}
+
+// NewTracker returns an empty Tracker.
+func NewTracker() *Tracker {
+	return &Tracker{seen: make(map[string]map[string]bool)}
+}
+
+// Fresh returns the findings of path that were not present the last time
+// it was analyzed, and records findings as its current state. Findings are
+// compared by type and matched value, so moving a secret to another line
+// does not report it again.
+func (t *Tracker) Fresh(path string, findings []analyzer.Finding) []analyzer.Finding {
+	previous := t.seen[path]
+	current := make(map[string]bool, len(findings))
+	fresh := make([]analyzer.Finding, 0)
+
+	for _, f := range findings {
+		key := f.Type + "\x00" + f.Match
+		if !previous[key] && !current[key] {
+			fresh = append(fresh, f)
+		}
+		current[key] = true
+	}
+
+	t.seen[path] = current
+	return fresh
+}
@@ -0,0 +1,259 @@
+// Package watch monitors a directory tree and reports the files that change
+// in it, so they can be scanned as soon as they are written. It uses inotify
+// where available and falls back to polling elsewhere. The walker's ignore
+// rules apply: ignored directories are never watched and only files the
+// walker would scan are reported.
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/scanner"
+)
+
+// Backend names reported by Watcher.Backend.
+const (
+	BackendInotify = "inotify"
+	BackendPolling = "polling"
+)
+
+// Config controls how a Watcher detects and batches changes.
+type Config struct {
+	// Extensions lists the file extensions to report. Nil uses
+	// scanner.DefaultTextExtensions.
+	Extensions map[string]bool
+
+	// Debounce is how long the tree must be quiet before a batch of
+	// changed files is delivered. Editors often write a file several times
+	// in a row; they are reported once.
+	Debounce time.Duration
+
+	// MaxDelay bounds how long a batch is held back by further changes. A
+	// file that is written continuously, such as a log, would otherwise
+	// keep the tree from ever being quiet for Debounce.
+	MaxDelay time.Duration
+
+	// PollInterval is how often the polling backend rescans the tree.
+	PollInterval time.Duration
+
+	// Polling forces the polling backend even where inotify is available.
+	Polling bool
+}
+
+// DefaultConfig returns a Config with a 500ms debounce, a 5s maximum delay
+// and a 2s poll interval.
+func DefaultConfig() Config {
+	return Config{
+		Debounce:     500 * time.Millisecond,
+		MaxDelay:     5 * time.Second,
+		PollInterval: 2 * time.Second,
+	}
+}
+
+// notifier is a change detection backend. run sends the paths of changed
+// files to changes until ctx is done.
+type notifier interface {
+	run(ctx context.Context, changes chan<- string) error
+}
+
+// Watcher reports changed files under a root directory.
+type Watcher struct {
+	root    string
+	cfg     Config
+	backend string
+	n       notifier
+}
+
+// New starts watching root. Empty fields in cfg fall back to DefaultConfig.
+// If inotify cannot be used (unsupported platform, watch limit reached) the
+// polling backend is used instead.
+func New(root string, cfg Config) (*Watcher, error) {
+	defaults := DefaultConfig()
+	if cfg.Debounce <= 0 {
+		cfg.Debounce = defaults.Debounce
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = defaults.MaxDelay
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaults.PollInterval
+	}
+	if cfg.Extensions == nil {
+		cfg.Extensions = scanner.DefaultTextExtensions
+	}
+
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", root)
+	}
+
+	w := &Watcher{root: root, cfg: cfg}
+	if !cfg.Polling {
+		if n, err := newInotifyNotifier(root); err == nil {
+			w.n, w.backend = n, BackendInotify
+			return w, nil
+		}
+	}
+	w.n, w.backend = newPollNotifier(root, cfg), BackendPolling
+	return w, nil
+}
+
+// Backend returns BackendInotify or BackendPolling.
+func (w *Watcher) Backend() string {
+	return w.backend
+}
+
+// Run calls fn with each debounced batch of changed files, sorted, until
+// ctx is cancelled. A batch is delivered once no change has arrived for
+// Debounce, or MaxDelay after its first change, whichever comes first. Files that were deleted again or that the walker would
+// not scan are left out. fn is called from a single goroutine.
+func (w *Watcher) Run(ctx context.Context, fn func(paths []string)) error {
+	changes := make(chan string, 256)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- w.n.run(ctx, changes)
+	}()
+
+	pending := make(map[string]bool)
+	var first time.Time // when the first change of the pending batch arrived
+	timer := time.NewTimer(w.cfg.Debounce)
+	stopTimer(timer)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case err := <-errc:
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+
+		case path := <-changes:
+			if len(pending) == 0 {
+				first = time.Now()
+			}
+			pending[path] = true
+			stopTimer(timer)
+			timer.Reset(min(w.cfg.Debounce, max(w.cfg.MaxDelay-time.Since(first), 0)))
+
+		case <-timer.C:
+			if paths := w.wanted(pending); len(paths) > 0 {
+				fn(paths)
+			}
+			pending = make(map[string]bool)
+		}
+	}
+}
+
+// stopTimer stops t and drains a tick that fired but was not received, so
+// a following Reset cannot be followed by the stale tick (go 1.21 timer
+// semantics). It must only be called from the goroutine receiving on t.C.
+func stopTimer(t *time.Timer) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
+		}
+	}
+}
+
+// wanted returns the pending paths that still exist and pass the walker's
+// file filter.
+func (w *Watcher) wanted(pending map[string]bool) []string {
+	paths := make([]string, 0, len(pending))
+	for path := range pending {
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if scanner.WantFile(fs.FileInfoToDirEntry(info), w.cfg.Extensions) {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// walkFiles calls fn for every file under root that is not inside an
+// ignored directory.
+func walkFiles(root string, fn func(path string, d fs.DirEntry)) {
+	_ = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != root && scanner.IgnoredDir(d.Name()) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		fn(path, d)
+		return nil
+	})
+}
+
+// fileState is what the polling backend compares between scans.
+type fileState struct {
+	size    int64
+	modTime int64
+}
+
+// pollNotifier detects changes by rescanning the tree periodically.
+type pollNotifier struct {
+	root     string
+	interval time.Duration
+	state    map[string]fileState
+}
+
+func newPollNotifier(root string, cfg Config) *pollNotifier {
+	p := &pollNotifier{root: root, interval: cfg.PollInterval}
+	p.state = p.snapshot()
+	return p
+}
+
+func (p *pollNotifier) snapshot() map[string]fileState {
+	state := make(map[string]fileState)
+	walkFiles(p.root, func(path string, d fs.DirEntry) {
+		if info, err := d.Info(); err == nil {
+			state[path] = fileState{size: info.Size(), modTime: info.ModTime().UnixNano()}
+		}
+	})
+	return state
+}
+
+func (p *pollNotifier) run(ctx context.Context, changes chan<- string) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current := p.snapshot()
+		for path, st := range current {
+			if old, ok := p.state[path]; ok && old == st {
+				continue
+			}
+			select {
+			case changes <- path:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		p.state = current
+	}
+}
@@ -0,0 +1,193 @@
+package watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tanmayshahane/kyubisweep/pkg/analyzer"
+)
+
+// =============================================================================
+// TEST: Watch Mode
+// Tests both backends against a temporary directory, plus sinks and tracking
+// =============================================================================
+
+func TestWatcherReportsChangedFiles(t *testing.T) {
+	for _, polling := range []bool{false, true} {
+		polling := polling
+		name := "inotify"
+		if polling {
+			name = "polling"
+		}
+
+		t.Run(name, func(t *testing.T) {
+			root := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(root, "node_modules"), 0755); err != nil {
+				t.Fatalf("Failed to create directory: %v", err)
+			}
+
+			w, err := New(root, Config{Debounce: 100 * time.Millisecond, PollInterval: 50 * time.Millisecond, Polling: polling})
+			if err != nil {
+				t.Fatalf("New failed: %v", err)
+			}
+			if !polling && w.Backend() != BackendInotify {
+				t.Skipf("inotify unavailable, got %s backend", w.Backend())
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			batches := make(chan []string, 10)
+			done := make(chan error, 1)
+			go func() {
+				done <- w.Run(ctx, func(paths []string) { batches <- paths })
+			}()
+
+			// Give the poller a baseline before changing anything
+			time.Sleep(100 * time.Millisecond)
+
+			write := func(rel, content string) {
+				path := filepath.Join(root, rel)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatalf("Failed to create directory: %v", err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", rel, err)
+				}
+			}
+			write("config.js", "token = 1\n")
+			write("config.js", "token = 2\n")
+			write("node_modules/lib.js", "ignored\n")
+			write("app.exe", "binary\n")
+			write("nested/dir/settings.yaml", "key: value\n")
+
+			seen := make(map[string]int)
+			deadline := time.After(5 * time.Second)
+			for seen["config.js"] == 0 || seen["nested/dir/settings.yaml"] == 0 {
+				select {
+				case batch := <-batches:
+					for _, path := range batch {
+						rel, _ := filepath.Rel(root, path)
+						seen[filepath.ToSlash(rel)]++
+					}
+				case <-deadline:
+					t.Fatalf("Timed out waiting for changes, saw %v", seen)
+				}
+			}
+
+			if seen["config.js"] != 1 {
+				t.Errorf("Expected config.js once after debouncing, saw it %d times", seen["config.js"])
+			}
+			for _, unwanted := range []string{"node_modules/lib.js", "app.exe"} {
+				if seen[unwanted] != 0 {
+					t.Errorf("Did not expect %s to be reported", unwanted)
+				}
+			}
+
+			cancel()
+			if err := <-done; err != context.Canceled {
+				t.Errorf("Expected context.Canceled from Run, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWatcherFlushesContinuouslyWrittenFile(t *testing.T) {
+	root := t.TempDir()
+	w, err := New(root, Config{Debounce: time.Second, MaxDelay: 200 * time.Millisecond, PollInterval: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	batches := make(chan []string, 10)
+	go w.Run(ctx, func(paths []string) { batches <- paths })
+	time.Sleep(50 * time.Millisecond)
+
+	// Append to a log faster than the debounce, for longer than MaxDelay
+	path := filepath.Join(root, "app.log")
+	writing := make(chan struct{})
+	go func() {
+		defer close(writing)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return
+		}
+		defer f.Close()
+		for i := 0; i < 150 && ctx.Err() == nil; i++ {
+			f.WriteString("line\n")
+			time.Sleep(20 * time.Millisecond)
+		}
+	}()
+
+	select {
+	case batch := <-batches:
+		if len(batch) != 1 || batch[0] != path {
+			t.Errorf("Expected a batch with app.log, got %v", batch)
+		}
+	case <-writing:
+		t.Fatal("Expected a batch while the file was still being written")
+	}
+	cancel()
+	<-writing
+}
+
+func TestNewRejectsFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	os.WriteFile(path, []byte("x"), 0644)
+
+	if _, err := New(path, DefaultConfig()); err == nil {
+		t.Error("Expected an error when watching a file")
+	}
+}
+
+func TestTrackerReportsOnlyNewFindings(t *testing.T) {
+	tracker := NewTracker()
+	aws := analyzer.Finding{Type: "AWS Access Key ID", Match: "AKIAZ7Q3M4N5P6R2S8TX", LineNumber: 1}
+	github := analyzer.Finding{Type: "GitHub Personal Access Token", Match: "ghp_x", LineNumber: 2}
+
+	steps := []struct {
+		name     string
+		findings []analyzer.Finding
+		want     int
+	}{
+		{"first save", []analyzer.Finding{aws}, 1},
+		{"unchanged", []analyzer.Finding{aws}, 0},
+		{"secret moved", []analyzer.Finding{{Type: aws.Type, Match: aws.Match, LineNumber: 9}}, 0},
+		{"secret added", []analyzer.Finding{aws, github}, 1},
+		{"secret removed", []analyzer.Finding{github}, 0},
+		{"secret re-added", []analyzer.Finding{aws, github}, 1},
+	}
+
+	for _, step := range steps {
+		if got := tracker.Fresh("config.env", step.findings); len(got) != step.want {
+			t.Errorf("%s: expected %d fresh findings, got %d", step.name, step.want, len(got))
+		}
+	}
+
+	if got := tracker.Fresh("other.env", []analyzer.Finding{aws}); len(got) != 1 {
+		t.Errorf("Expected files to be tracked separately, got %d fresh findings", len(got))
+	}
+}
+
+func TestJSONLinesSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewJSONLinesSink(&buf)
+	sink.Emit(analyzer.Finding{Type: "AWS Access Key ID", FilePath: "a.env"})
+	sink.Emit(analyzer.Finding{Type: "Generic Secret", FilePath: "b.env"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d: %q", len(lines), buf.String())
+	}
+	var f analyzer.Finding
+	if err := json.Unmarshal([]byte(lines[1]), &f); err != nil || f.Type != "Generic Secret" {
+		t.Errorf("Expected the second line to decode to the second finding, got %+v, %v", f, err)
+	}
+}